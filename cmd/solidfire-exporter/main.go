@@ -1,11 +1,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	log "github.com/amoghe/distillog"
@@ -22,25 +24,15 @@ var (
 	buildTime string // when the executable was built
 )
 
+// clusterNameRetryInterval is how often the name of a cluster that was
+// unreachable at startup is looked up again.
+const clusterNameRetryInterval = time.Minute
+
 func init() {
 	flag.CommandLine.SortFlags = true
 	flag.StringP(solidfire.ConfigFile, "c", solidfire.DefaultConfigFile, fmt.Sprintf("Specify configuration filename."))
-	flag.Parse()
-	viper.BindPFlags(flag.CommandLine)
-
-	// extracts the filename from the config filename passed on --config flag (e.g /etc/solidfire-exporter/config.yaml)
-	viper.SetConfigName(filepath.Base(viper.GetString(solidfire.ConfigFile)))
-	viper.SetConfigType("yaml")
-	// extracts the directory path from the config filename passed on --config flag (e.g /etc/solidfire-exporter/config.yaml)
-	viper.AddConfigPath(filepath.Dir(viper.GetString(solidfire.ConfigFile)))
-	viper.AddConfigPath(".")
-
-	if err := viper.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
-			log.Warningf("No config file found.")
-		}
-	} else {
-		log.Infof("Found configuration file on %v ", viper.GetViper().ConfigFileUsed())
+	for _, name := range prom.CollectorNames() {
+		flag.Bool(collectorKey(name), prom.CollectorEnabledByDefault(name), fmt.Sprintf("Enable the %s collector.", name))
 	}
 
 	viper.SetDefault(solidfire.ConfigFile, solidfire.DefaultConfigFile)
@@ -54,38 +46,174 @@ func init() {
 	viper.SetDefault(solidfire.HTTPClientTimeout, solidfire.DefaultHTTPClientTimeout)
 	viper.SetDefault(solidfire.CollectTimeout, solidfire.DefaultCollectTimeout)
 
+	viper.SetDefault(solidfire.VolumesIdleAfter, solidfire.DefaultVolumesIdleAfter)
+
+	viper.SetDefault(solidfire.PollEnabled, solidfire.DefaultPollEnabled)
+	viper.SetDefault(solidfire.PollInterval, solidfire.DefaultPollInterval)
+
+	viper.SetDefault(solidfire.RetryMaxAttempts, solidfire.DefaultRetryMaxAttempts)
+	viper.SetDefault(solidfire.RetryBaseBackoff, solidfire.DefaultRetryBaseBackoff)
+	viper.SetDefault(solidfire.RetryMaxBackoff, solidfire.DefaultRetryMaxBackoff)
+	viper.SetDefault(solidfire.RetryErrorNames, solidfire.DefaultRetryErrorNames)
+	viper.SetDefault(solidfire.RetryStatusCodes, solidfire.DefaultRetryStatusCodes)
+
 	viper.AutomaticEnv()
 	viper.SetEnvPrefix("SOLIDFIRE")
 	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 
 }
+
+// loadConfig parses the flags and reads the config file. It runs from main
+// rather than init so the package can be tested.
+func loadConfig() {
+	flag.Parse()
+	viper.BindPFlags(flag.CommandLine)
+
+	// extracts the filename from the config filename passed on --config flag (e.g /etc/solidfire-exporter/config.yaml)
+	viper.SetConfigName(filepath.Base(viper.GetString(solidfire.ConfigFile)))
+	viper.SetConfigType("yaml")
+	// extracts the directory path from the config filename passed on --config flag (e.g /etc/solidfire-exporter/config.yaml)
+	viper.AddConfigPath(filepath.Dir(viper.GetString(solidfire.ConfigFile)))
+	viper.AddConfigPath(".")
+
+	if err := viper.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
+			log.Warningf("No config file found.")
+		}
+	} else {
+		log.Infof("Found configuration file on %v ", viper.GetViper().ConfigFileUsed())
+	}
+}
+
 func main() {
+	loadConfig()
 	log.Infof("Version: %v", sha1ver)
 	log.Infof("Built: %v", buildTime)
 	listenAddress := fmt.Sprintf("%v", viper.GetString(solidfire.ListenAddress))
 
-	sfClient, err := solidfire.NewSolidfireClient()
+	collectTimeout := time.Second * time.Duration(viper.GetInt(solidfire.CollectTimeout))
+	collectors := enabledCollectors()
+	cacheTTLs, err := solidfire.ParseCacheTTLs(viper.GetStringMapString(solidfire.CacheTTLs))
 	if err != nil {
-		log.Errorf("error initializing solidfire client: %s\n", err.Error())
+		log.Errorf("error loading cache TTLs: %s\n", err.Error())
 		os.Exit(1)
 	}
-	collectTimeout := time.Second * time.Duration(viper.GetInt(solidfire.CollectTimeout))
-	solidfireExporter, err := prom.NewCollector(&prom.CollectorOpts{Client: sfClient, Timeout: collectTimeout})
+	volumeFilter, err := loadVolumeFilter()
 	if err != nil {
-		log.Errorf("error initializing collector: %s\n", err.Error())
+		log.Errorf("error loading volume filter: %s\n", err.Error())
+		os.Exit(1)
+	}
+	var volumeLabels prom.VolumeLabelsConfig
+	if err := viper.UnmarshalKey(solidfire.VolumesLabels, &volumeLabels); err != nil {
+		log.Errorf("error loading volume labels: %s\n", err.Error())
 		os.Exit(1)
 	}
-	prometheus.MustRegister(solidfireExporter)
+	idleAfter := viper.GetDuration(solidfire.VolumesIdleAfter)
+	clusters, err := loadClusters()
+	if err != nil {
+		log.Errorf("error loading clusters: %s\n", err.Error())
+		os.Exit(1)
+	}
+	reports := prom.NewVolumeReportHandler(map[string]*prom.SolidfireCollector{})
+	if len(clusters) == 0 {
+		sfClient, err := solidfire.NewSolidfireClient()
+		if err != nil {
+			log.Errorf("error initializing solidfire client: %s\n", err.Error())
+			os.Exit(1)
+		}
+		rpcMetrics := prom.NewRPCMetrics()
+		sfClient.Observer = rpcMetrics
+		negotiateAPIVersion(sfClient, collectTimeout)
+		solidfireExporter, err := prom.NewCollector(&prom.CollectorOpts{Client: withCache(sfClient, cacheTTLs, rpcMetrics), Timeout: collectTimeout, Collectors: collectors, VolumeFilter: volumeFilter, VolumeLabels: volumeLabels, IdleAfter: idleAfter})
+		if err != nil {
+			log.Errorf("error initializing collector: %s\n", err.Error())
+			os.Exit(1)
+		}
+		prometheus.MustRegister(pollOrCollect(solidfireExporter), rpcMetrics)
+		reports.Add(solidfire.LocalCluster, solidfireExporter)
+	}
+	var registeredMu sync.Mutex
+	registered := map[string]bool{}
+	addCluster := func(name string, cluster solidfire.ClusterConfig, client solidfire.Interface, rpcMetrics *prom.RPCMetrics) error {
+		registeredMu.Lock()
+		defer registeredMu.Unlock()
+		if registered[name] {
+			return fmt.Errorf("duplicate cluster name %q, set a unique name for %s", name, cluster.Endpoint)
+		}
+		solidfireExporter, err := prom.NewCollector(&prom.CollectorOpts{Client: client, Timeout: collectTimeout, Collectors: collectors, VolumeFilter: volumeFilter, VolumeLabels: volumeLabels, IdleAfter: idleAfter})
+		if err != nil {
+			return fmt.Errorf("error initializing collector for %s: %w", name, err)
+		}
+		if err := registerCluster(prometheus.DefaultRegisterer, name, pollOrCollect(solidfireExporter), rpcMetrics); err != nil {
+			return fmt.Errorf("error registering cluster %s: %w", name, err)
+		}
+		registered[name] = true
+		reports.Add(name, solidfireExporter)
+		log.Infof("Collecting cluster %s from %s", name, cluster.Endpoint)
+		return nil
+	}
+	for _, cluster := range clusters {
+		rpcMetrics := prom.NewRPCMetrics()
+		sfClient, err := solidfire.NewSolidfireClientWithOpts(&solidfire.ClientOpts{
+			Endpoint: cluster.Endpoint,
+			Username: cluster.Username,
+			Password: cluster.Password,
+			Insecure: *cluster.Insecure,
+			Timeout:  time.Duration(cluster.Timeout) * time.Second,
+			Retry:    solidfire.RetryPolicyFromConfig(),
+			Observer: rpcMetrics,
+		})
+		if err != nil {
+			log.Errorf("error initializing solidfire client for %s: %s\n", cluster.Endpoint, err.Error())
+			os.Exit(1)
+		}
+		negotiateAPIVersion(sfClient, collectTimeout)
+		client := withCache(sfClient, cacheTTLs, rpcMetrics)
+		name, err := clusterName(sfClient, cluster, collectTimeout)
+		if err != nil {
+			// The other clusters are collected meanwhile; this one is added
+			// once its name resolves.
+			log.Warningf("%s, retrying every %v\n", err.Error(), clusterNameRetryInterval)
+			go func(cluster solidfire.ClusterConfig) {
+				name := resolveClusterName(sfClient, cluster, collectTimeout, clusterNameRetryInterval)
+				if err := addCluster(name, cluster, client, rpcMetrics); err != nil {
+					log.Errorf("%s, not collecting %s\n", err.Error(), cluster.Endpoint)
+				}
+			}(cluster)
+			continue
+		}
+		if err := addCluster(name, cluster, client, rpcMetrics); err != nil {
+			log.Errorf("%s\n", err.Error())
+			os.Exit(1)
+		}
+	}
 	http.Handle("/metrics", promhttp.Handler())
+	http.Handle("/report/volumes", reports)
+
+	modules, err := loadModules()
+	if err != nil {
+		log.Errorf("error loading modules: %s\n", err.Error())
+		os.Exit(1)
+	}
+	http.Handle("/probe", prom.NewProbeHandler(&prom.ProbeHandlerOpts{
+		Modules:      modules,
+		Timeout:      collectTimeout,
+		Retry:        solidfire.RetryPolicyFromConfig(),
+		Collectors:   collectors,
+		CacheTTLs:    cacheTTLs,
+		VolumeFilter: volumeFilter,
+		VolumeLabels: volumeLabels,
+		IdleAfter:    idleAfter,
+	}))
 
 	for _, key := range viper.AllKeys() {
 		value := viper.Get(key)
-		if key == solidfire.Password {
+		if key == solidfire.Password || key == solidfire.Clusters || strings.HasSuffix(key, ".password") {
 			value = "[REDACTED]"
 		}
 		log.Infof("Booting with setting %s: %v", key, value)
 	}
-	log.Infof("Booted and listening on %v/metrics\n", listenAddress)
+	log.Infof("Booted and listening on %v/metrics, %v/probe and %v/report/volumes\n", listenAddress, listenAddress, listenAddress)
 	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprintf(w, "UP")
 	})
@@ -95,3 +223,174 @@ func main() {
 		log.Errorln(err)
 	}
 }
+
+// withCache wraps client in a response cache when any cache TTL is configured.
+func withCache(client *solidfire.Client, ttls map[solidfire.RPC]time.Duration, rpcMetrics *prom.RPCMetrics) solidfire.Interface {
+	if len(ttls) == 0 {
+		return client
+	}
+	return solidfire.NewCachedClient(client, ttls, rpcMetrics)
+}
+
+// pollOrCollect returns the collector to register on /metrics: the collector
+// itself, or a background poller serving cached results when poll.enabled is
+// set.
+func pollOrCollect(collector *prom.SolidfireCollector) prometheus.Collector {
+	if !viper.GetBool(solidfire.PollEnabled) {
+		return collector
+	}
+	intervals := map[string]time.Duration{}
+	for name, value := range viper.GetStringMapString(solidfire.PollIntervals) {
+		interval, err := time.ParseDuration(value)
+		if err != nil {
+			log.Errorf("invalid poll interval for %s: %s\n", name, err.Error())
+			os.Exit(1)
+		}
+		intervals[name] = interval
+	}
+	poller, err := prom.NewPoller(&prom.PollerOpts{
+		Collector: collector,
+		Interval:  viper.GetDuration(solidfire.PollInterval),
+		Intervals: intervals,
+	})
+	if err != nil {
+		log.Errorf("error initializing poller: %s\n", err.Error())
+		os.Exit(1)
+	}
+	go poller.Run(context.Background())
+	return poller
+}
+
+// collectorKey is both the --collector.<name> flag and the config key.
+func collectorKey(name string) string {
+	return "collector." + name
+}
+
+// enabledCollectors reads the collector switches from flags, environment and
+// config file.
+func enabledCollectors() map[string]bool {
+	enabled := map[string]bool{}
+	for _, name := range prom.CollectorNames() {
+		enabled[name] = viper.GetBool(collectorKey(name))
+	}
+	return enabled
+}
+
+// loadVolumeFilter reads the volumes.include and volumes.exclude rules. The
+// exclude rules default to prom.DefaultVolumeExclude unless configured, so an
+// empty list reports every volume.
+func loadVolumeFilter() (prom.VolumeFilterConfig, error) {
+	config := prom.VolumeFilterConfig{Exclude: prom.DefaultVolumeExclude}
+	if err := viper.UnmarshalKey(solidfire.VolumesInclude, &config.Include); err != nil {
+		return config, err
+	}
+	if viper.IsSet(solidfire.VolumesExclude) {
+		config.Exclude = nil
+		if err := viper.UnmarshalKey(solidfire.VolumesExclude, &config.Exclude); err != nil {
+			return config, err
+		}
+	}
+	return config, nil
+}
+
+// loadClusters reads the clusters: list from the config file. Credentials,
+// timeout and insecure fall back to the client.* settings when omitted.
+func loadClusters() ([]solidfire.ClusterConfig, error) {
+	var clusters []solidfire.ClusterConfig
+	if err := viper.UnmarshalKey(solidfire.Clusters, &clusters); err != nil {
+		return nil, err
+	}
+	for i, cluster := range clusters {
+		if cluster.Endpoint == "" {
+			return nil, fmt.Errorf("cluster entry %d has no endpoint", i)
+		}
+		if cluster.Username == "" {
+			cluster.Username = viper.GetString(solidfire.Username)
+		}
+		if cluster.Password == "" {
+			cluster.Password = viper.GetString(solidfire.Password)
+		}
+		if cluster.Timeout == 0 {
+			cluster.Timeout = viper.GetInt(solidfire.HTTPClientTimeout)
+		}
+		if cluster.Insecure == nil {
+			insecure := viper.GetBool(solidfire.InsecureSSL)
+			cluster.Insecure = &insecure
+		}
+		clusters[i] = cluster
+	}
+	return clusters, nil
+}
+
+// negotiateAPIVersion resolves the API version at startup so a bad endpoint is
+// reported early. On failure the client retries on the next scrape.
+func negotiateAPIVersion(client *solidfire.Client, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	version, err := client.NegotiateAPIVersion(ctx)
+	if err != nil {
+		log.Warningf("could not determine API version for %s, will retry on scrape: %v", client.RPCEndpoint, err)
+		return
+	}
+	log.Infof("Using API version %s for %s", version, client.RPCEndpoint)
+}
+
+// clusterName returns the configured cluster name, or the name reported by
+// GetClusterInfo. The name is the sfcluster label value, so it is not guessed
+// when the cluster is unreachable: series would split across restarts.
+func clusterName(client solidfire.Interface, cluster solidfire.ClusterConfig, timeout time.Duration) (string, error) {
+	if cluster.Name != "" {
+		return cluster.Name, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	info, err := client.GetClusterInfo(ctx)
+	if err != nil {
+		return "", fmt.Errorf("could not resolve cluster name for %s, set name in its clusters entry: %w", cluster.Endpoint, err)
+	}
+	if info.Result.ClusterInfo.Name == "" {
+		return "", fmt.Errorf("cluster %s reported no name, set name in its clusters entry", cluster.Endpoint)
+	}
+	return info.Result.ClusterInfo.Name, nil
+}
+
+// resolveClusterName retries clusterName every interval until it succeeds.
+func resolveClusterName(client solidfire.Interface, cluster solidfire.ClusterConfig, timeout, interval time.Duration) string {
+	for {
+		time.Sleep(interval)
+		name, err := clusterName(client, cluster, timeout)
+		if err == nil {
+			return name
+		}
+		log.Warningf("%s, retrying in %v\n", err.Error(), interval)
+	}
+}
+
+// registerCluster registers the collectors of one clusters entry, adding the
+// sfcluster label to every metric.
+func registerCluster(registerer prometheus.Registerer, name string, collectors ...prometheus.Collector) error {
+	wrapped := prometheus.WrapRegistererWith(prometheus.Labels{prom.ClusterLabel: name}, registerer)
+	for _, collector := range collectors {
+		if err := wrapped.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadModules reads the named probe modules from the config file. Only
+// configured modules are served; the client.* credentials are never used by
+// /probe.
+func loadModules() (map[string]solidfire.Module, error) {
+	modules := map[string]solidfire.Module{}
+	if err := viper.UnmarshalKey(solidfire.Modules, &modules); err != nil {
+		return nil, err
+	}
+	for name, module := range modules {
+		if module.Timeout == 0 {
+			module.Timeout = solidfire.DefaultHTTPClientTimeout
+		}
+		modules[name] = module
+	}
+	return modules, nil
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mjavier2k/solidfire-exporter/pkg/solidfire"
+	"github.com/mjavier2k/solidfire-exporter/pkg/testutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func Test_loadClusters(t *testing.T) {
+	tests := []struct {
+		name     string
+		clusters []map[string]interface{}
+		want     []solidfire.ClusterConfig
+		wantErr  string
+	}{
+		{
+			name:     "omitted settings fall back to client.*",
+			clusters: []map[string]interface{}{{"name": "sf01", "endpoint": "https://10.0.0.1/json-rpc/11.3"}},
+			want: []solidfire.ClusterConfig{{
+				Name:     "sf01",
+				Endpoint: "https://10.0.0.1/json-rpc/11.3",
+				Username: "global-user",
+				Password: "global-pass",
+				Insecure: boolPtr(true),
+				Timeout:  45,
+			}},
+		},
+		{
+			name: "explicit settings win, including insecure: false",
+			clusters: []map[string]interface{}{{
+				"name":     "sf02",
+				"endpoint": "https://10.0.0.2",
+				"username": "user",
+				"password": "pass",
+				"insecure": false,
+				"timeout":  10,
+			}},
+			want: []solidfire.ClusterConfig{{
+				Name:     "sf02",
+				Endpoint: "https://10.0.0.2",
+				Username: "user",
+				Password: "pass",
+				Insecure: boolPtr(false),
+				Timeout:  10,
+			}},
+		},
+		{
+			name:     "missing endpoint",
+			clusters: []map[string]interface{}{{"name": "sf03"}},
+			wantErr:  "cluster entry 0 has no endpoint",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			defer viper.Reset()
+			viper.Set(solidfire.Username, "global-user")
+			viper.Set(solidfire.Password, "global-pass")
+			viper.Set(solidfire.InsecureSSL, true)
+			viper.Set(solidfire.HTTPClientTimeout, 45)
+			viper.Set(solidfire.Clusters, tt.clusters)
+
+			got, err := loadClusters()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_clusterName(t *testing.T) {
+	resolved := solidfire.GetClusterInfoResponse{}
+	resolved.Result.ClusterInfo.Name = "reported"
+
+	tests := []struct {
+		name     string
+		cluster  solidfire.ClusterConfig
+		response solidfire.GetClusterInfoResponse
+		err      error
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:    "configured name is used as-is",
+			cluster: solidfire.ClusterConfig{Name: "configured", Endpoint: "https://10.0.0.1"},
+			want:    "configured",
+		},
+		{
+			name:     "name reported by GetClusterInfo",
+			cluster:  solidfire.ClusterConfig{Endpoint: "https://10.0.0.1"},
+			response: resolved,
+			want:     "reported",
+		},
+		{
+			name:    "unreachable cluster without a name is an error",
+			cluster: solidfire.ClusterConfig{Endpoint: "https://10.0.0.1"},
+			err:     errors.New("connection refused"),
+			wantErr: true,
+		},
+		{
+			name:    "cluster reporting an empty name is an error",
+			cluster: solidfire.ClusterConfig{Endpoint: "https://10.0.0.1"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := new(testutils.MockSolidfireClient)
+			client.On(string(solidfire.RPCGetClusterInfo), mock.Anything).Return(tt.response, tt.err)
+
+			got, err := clusterName(client, tt.cluster, time.Second)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_registerCluster(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	for _, name := range []string{"sf01", "sf02"} {
+		gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "solidfire_up"})
+		gauge.Set(1)
+		require.NoError(t, registerCluster(registry, name, gauge))
+	}
+	assert.Error(t, registerCluster(registry, "sf01", prometheus.NewGauge(prometheus.GaugeOpts{Name: "solidfire_up"})), "duplicate cluster")
+
+	got := testutils.PrometheusOutput(t, registry, "solidfire")
+	assert.Equal(t, []string{
+		`solidfire_up{sfcluster="sf01"} 1`,
+		`solidfire_up{sfcluster="sf02"} 1`,
+	}, got)
+}
+
+func Test_resolveClusterName(t *testing.T) {
+	resolved := solidfire.GetClusterInfoResponse{}
+	resolved.Result.ClusterInfo.Name = "reported"
+	client := new(testutils.MockSolidfireClient)
+	client.On(string(solidfire.RPCGetClusterInfo), mock.Anything).Return(solidfire.GetClusterInfoResponse{}, errors.New("connection refused")).Twice()
+	client.On(string(solidfire.RPCGetClusterInfo), mock.Anything).Return(resolved, nil).Once()
+
+	got := resolveClusterName(client, solidfire.ClusterConfig{Endpoint: "https://10.0.0.1"}, time.Second, time.Millisecond)
+	assert.Equal(t, "reported", got)
+	client.AssertNumberOfCalls(t, string(solidfire.RPCGetClusterInfo), 3)
+}
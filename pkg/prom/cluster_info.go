@@ -0,0 +1,92 @@
+package prom
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mjavier2k/solidfire-exporter/pkg/solidfire"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// getClusterInfo gets the cluster info once per scrape for the cluster_info
+// and ensemble collectors.
+func (c *SolidfireCollector) getClusterInfo(ctx context.Context) (solidfire.GetClusterInfoResponse, error) {
+	return shared(ctx, string(solidfire.RPCGetClusterInfo), func() (solidfire.GetClusterInfoResponse, error) {
+		return c.client.GetClusterInfo(ctx)
+	})
+}
+
+// collectClusterInfo reports the identity of the cluster, its version and the
+// software version of every node, so nodes left behind by a partial upgrade
+// stand out.
+func (c *SolidfireCollector) collectClusterInfo(ctx context.Context, ch chan<- prometheus.Metric) error {
+	info, err := c.getClusterInfo(ctx)
+	if err != nil {
+		return err
+	}
+	versions, err := c.client.GetClusterVersionInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	cluster := info.Result.ClusterInfo
+	ensemble := append([]string{}, cluster.Ensemble...)
+	sort.Strings(ensemble)
+	ch <- prometheus.MustNewConstMetric(
+		c.metrics.ClusterInfo,
+		prometheus.GaugeValue,
+		1,
+		cluster.Name,
+		cluster.UUID,
+		cluster.UniqueID,
+		cluster.Mvip,
+		cluster.Svip,
+		cluster.EncryptionAtRestState,
+		cluster.SoftwareEncryptionAtRestState,
+		strconv.Itoa(cluster.RepCount),
+		strings.Join(ensemble, ","),
+	)
+
+	v := versions.Result
+	ch <- prometheus.MustNewConstMetric(
+		c.metrics.ClusterVersionInfo,
+		prometheus.GaugeValue,
+		1,
+		v.ClusterAPIVersion,
+		v.ClusterVersion,
+		v.PendingClusterVersion,
+	)
+
+	var upgradePending float64 = 0
+	if v.PendingClusterVersion != "" && v.PendingClusterVersion != v.ClusterVersion {
+		upgradePending = 1
+	}
+	ch <- prometheus.MustNewConstMetric(
+		c.metrics.ClusterUpgradePending,
+		prometheus.GaugeValue,
+		upgradePending,
+	)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	nodeVersions := map[string]bool{}
+	for _, node := range v.ClusterVersionInfo {
+		nodeVersions[node.NodeVersion] = true
+		ch <- prometheus.MustNewConstMetric(
+			c.metrics.NodeVersionInfo,
+			prometheus.GaugeValue,
+			1,
+			strconv.Itoa(node.NodeID),
+			c.nodeName(node.NodeID),
+			node.NodeVersion,
+		)
+	}
+	ch <- prometheus.MustNewConstMetric(
+		c.metrics.ClusterNodeVersions,
+		prometheus.GaugeValue,
+		float64(len(nodeVersions)),
+	)
+	return nil
+}
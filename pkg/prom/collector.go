@@ -2,48 +2,115 @@ package prom
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
-	"regexp"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	log "github.com/amoghe/distillog"
 	"github.com/mjavier2k/solidfire-exporter/pkg/solidfire"
-	"golang.org/x/sync/errgroup"
 
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 type volumeMetadata struct {
-	VolumeId  string
-	Name      string
-	AccountId string
+	VolumeId string
+	Name     string
+	// OwnerId is the owner_id attribute, exported as the account_id label.
+	OwnerId string
+
+	// Matched by the volume filter.
+	Status                 string
+	AccountID              int
+	AccountName            string
+	VolumeAccessGroupIDs   []int
+	VolumeAccessGroupNames []string
+	Attributes             map[string]string
+
+	QoS volumeQoS
+
+	// Reported by solidfire_volume_info and the volume lifecycle metrics.
+	Access          string
+	BlockSize       int
+	Enable512E      bool
+	Iqn             string
+	ScsiNAADeviceID string
+	ScsiEUIDeviceID string
+	VolumeUUID      string
+	// VolumeConsistencyGroupUUID is shared by the volumes of a consistency
+	// group.
+	VolumeConsistencyGroupUUID string
+	ProtectionScheme           string
+	SliceCount                 int
+	CreateTime                 time.Time
+	LastAccessTimeIO           time.Time
+	TotalSize                  int64
+	// PurgeTime is only set for deleted volumes.
+	PurgeTime time.Time
+
+	EnableSnapMirrorReplication bool
+}
+
+// volumeQoS are the QoS settings of a volume from ListVolumes.
+type volumeQoS struct {
+	MinIOPS   int
+	MaxIOPS   int
+	BurstIOPS int
+	BurstTime int
+	// Curve maps IO sizes in bytes to their IOPS cost.
+	Curve map[int]int
 }
 
 func (v *volumeMetadata) Values() []string {
 	return []string{
 		v.VolumeId,
 		v.Name,
-		v.AccountId,
+		v.OwnerId,
 	}
 }
 
 type SolidfireCollector struct {
-	client             solidfire.Interface
-	timeout            time.Duration
-	volumeMetadataByID map[int]volumeMetadata
-	nodesNamesByID     map[int]string
+	mu      sync.Mutex
+	client  solidfire.Interface
+	timeout time.Duration
+	volumes *inventory[volumeMetadata]
+	nodes   *inventory[string]
+	// volumeFilter selects the volumes of the per-volume metrics.
+	volumeFilter *volumeFilter
+	volumeLabels VolumeLabelsConfig
+	// metrics are the metric descriptions, with the extra volume labels.
+	metrics    *Descriptions
+	subsystems []subsystem
+	// idleAfter is the time without I/O after which a volume is idle.
+	idleAfter time.Duration
+	// volumeReport is the volume state report of the last volume_state run.
+	volumeReport VolumeReport
+	// ensemble tracks the cluster master across ensemble runs.
+	ensemble *ensembleState
 }
 type CollectorOpts struct {
 	Client  solidfire.Interface
 	Timeout time.Duration
+	// Collectors enables or disables collectors by name. Collectors not
+	// listed use their default.
+	Collectors map[string]bool
+	// VolumeFilter selects the volumes of the per-volume metrics. The zero
+	// value reports every volume.
+	VolumeFilter VolumeFilterConfig
+	// VolumeLabels adds optional labels to the per-volume metrics.
+	VolumeLabels VolumeLabelsConfig
+	// IdleAfter is the time without I/O after which a volume is idle.
+	// Defaults to solidfire.DefaultVolumesIdleAfter.
+	IdleAfter time.Duration
 }
 
+const ClusterLabel = "sfcluster"
+
 var (
-	mu                    sync.Mutex
 	MetricDescriptions    = NewMetricDescriptions("solidfire")
 	possibleDriveStatuses = []string{"active", "available", "erasing", "failed", "removing"}
 )
@@ -64,154 +131,265 @@ func strCompare(str1 string, str2 string) int {
 }
 
 func (c *SolidfireCollector) Describe(ch chan<- *prometheus.Desc) {
-	ch <- MetricDescriptions.upDesc
-
-	ch <- MetricDescriptions.VolumeActualIOPS
-	ch <- MetricDescriptions.VolumeAverageIOPSizeBytes
-	ch <- MetricDescriptions.VolumeBurstIOPSCredit
-	ch <- MetricDescriptions.VolumeClientQueueDepth
-	ch <- MetricDescriptions.VolumeLatencySeconds
-	ch <- MetricDescriptions.VolumeNonZeroBlocks
-	ch <- MetricDescriptions.VolumeReadBytesTotal
-	ch <- MetricDescriptions.VolumeReadLatencySecondsTotal
-	ch <- MetricDescriptions.VolumeReadOpsTotal
-	ch <- MetricDescriptions.VolumeThrottle
-	ch <- MetricDescriptions.VolumeUnalignedReadsTotal
-	ch <- MetricDescriptions.VolumeUnalignedWritesTotal
-	ch <- MetricDescriptions.VolumeSizeBytes
-	ch <- MetricDescriptions.VolumeUtilization
-	ch <- MetricDescriptions.VolumeWriteBytesTotal
-	ch <- MetricDescriptions.VolumeWriteLatencyTotal
-	ch <- MetricDescriptions.VolumeWriteOpsTotal
-	ch <- MetricDescriptions.VolumeStatsZeroBlocks
-
-	ch <- MetricDescriptions.ClusterActiveBlockSpaceBytes
-	ch <- MetricDescriptions.ClusterActiveSessions
-	ch <- MetricDescriptions.ClusterAverageIOPS
-	ch <- MetricDescriptions.ClusterClusterRecentIOSizeBytes
-	ch <- MetricDescriptions.ClusterCurrentIOPS
-	ch <- MetricDescriptions.ClusterMaxIOPS
-	ch <- MetricDescriptions.ClusterMaxOverProvisionableSpaceBytes
-	ch <- MetricDescriptions.ClusterMaxProvisionedSpaceBytes
-	ch <- MetricDescriptions.ClusterMaxUsedMetadataSpaceBytes
-	ch <- MetricDescriptions.ClusterMaxUsedSpaceBytes
-	ch <- MetricDescriptions.ClusterNonZeroBlocks
-	ch <- MetricDescriptions.ClusterPeakActiveSessions
-	ch <- MetricDescriptions.ClusterPeakIOPS
-	ch <- MetricDescriptions.ClusterProvisionedSpaceBytes
-	ch <- MetricDescriptions.ClusterSnapshotNonZeroBlocks
-	ch <- MetricDescriptions.ClusterIOPSTotal
-	ch <- MetricDescriptions.ClusterUniqueBlocks
-	ch <- MetricDescriptions.ClusterUniqueBlocksUsedSpaceBytes
-	ch <- MetricDescriptions.ClusterUsedMetadataSpaceBytes
-	ch <- MetricDescriptions.ClusterUsedMetadataSpaceInSnapshotsBytes
-	ch <- MetricDescriptions.ClusterUsedSpaceBytes
-	ch <- MetricDescriptions.ClusterZeroBlocks
-	ch <- MetricDescriptions.ClusterThinProvisioningFactor
-	ch <- MetricDescriptions.ClusterDeDuplicationFactor
-	ch <- MetricDescriptions.ClusterCompressionFactor
-	ch <- MetricDescriptions.ClusterEfficiencyFactor
-
-	ch <- MetricDescriptions.ClusterActiveFaults
-
-	ch <- MetricDescriptions.NodeSamples
-	ch <- MetricDescriptions.NodeCPUPercentage
-	ch <- MetricDescriptions.NodeCPUSecondsTotal
-	ch <- MetricDescriptions.NodeInterfaceInBytesTotal
-	ch <- MetricDescriptions.NodeInterfaceOutBytesTotal
-	ch <- MetricDescriptions.NodeInterfaceUtilizationPercentage
-	ch <- MetricDescriptions.NodeReadLatencyTotal
-	ch <- MetricDescriptions.NodeUsedMemoryBytes
-	ch <- MetricDescriptions.NodeWriteLatencyTotal
-	ch <- MetricDescriptions.NodeLoadHistogram
-
-	ch <- MetricDescriptions.NodeInfo
-
-	ch <- MetricDescriptions.ClusterActualIOPS
-	ch <- MetricDescriptions.ClusterAverageIOBytes
-	ch <- MetricDescriptions.ClusterClientQueueDepth
-	ch <- MetricDescriptions.ClusterThroughputUtilization
-	ch <- MetricDescriptions.ClusterLatencySeconds
-	ch <- MetricDescriptions.ClusterNormalizedIOPS
-	ch <- MetricDescriptions.ClusterReadBytesTotal
-	ch <- MetricDescriptions.ClusterLastSampleReadBytes
-	ch <- MetricDescriptions.ClusterReadLatencySeconds
-	ch <- MetricDescriptions.ClusterReadLatencyTotal
-	ch <- MetricDescriptions.ClusterReadOpsTotal
-	ch <- MetricDescriptions.ClusterLastSampleReadOps
-	ch <- MetricDescriptions.ClusterSamplePeriodSeconds
-	ch <- MetricDescriptions.ClusterServices
-	ch <- MetricDescriptions.ClusterExpectedServices
-	ch <- MetricDescriptions.ClusterUnalignedReadsTotal
-	ch <- MetricDescriptions.ClusterUnalignedWritesTotal
-	ch <- MetricDescriptions.ClusterWriteBytesTotal
-	ch <- MetricDescriptions.ClusterLastSampleWriteBytes
-	ch <- MetricDescriptions.ClusterWriteLatency
-	ch <- MetricDescriptions.ClusterWriteLatencyTotal
-	ch <- MetricDescriptions.ClusterWriteOpsTotal
-	ch <- MetricDescriptions.ClusterLastSampleWriteOps
-
-	ch <- MetricDescriptions.ClusterBlockFullness
-	ch <- MetricDescriptions.ClusterFullness
-	ch <- MetricDescriptions.ClusterMaxMetadataOverProvisionFactor
-	ch <- MetricDescriptions.ClusterMetadataFullness
-	ch <- MetricDescriptions.ClusterSliceReserveUsedThresholdPercentage
-	ch <- MetricDescriptions.ClusterStage2AwareThresholdPercentage
-	ch <- MetricDescriptions.ClusterStage2BlockThresholdBytes
-	ch <- MetricDescriptions.ClusterStage3BlockThresholdBytes
-	ch <- MetricDescriptions.ClusterStage3BlockThresholdPercentage
-	ch <- MetricDescriptions.ClusterStage3LowThresholdPercentage
-	ch <- MetricDescriptions.ClusterStage4BlockThresholdBytes
-	ch <- MetricDescriptions.ClusterStage4CriticalThreshold
-	ch <- MetricDescriptions.ClusterStage5BlockThresholdBytes
-	ch <- MetricDescriptions.ClusterTotalBytes
-	ch <- MetricDescriptions.ClusterTotalMetadataBytes
-	ch <- MetricDescriptions.ClusterUsedBytes
-	ch <- MetricDescriptions.ClusterUsedMetadataBytes
-
-	ch <- MetricDescriptions.DriveStatus
-	ch <- MetricDescriptions.DriveCapacityBytes
-
-	ch <- MetricDescriptions.NodeISCSISessions
-
-	ch <- MetricDescriptions.VolumeCount
-	ch <- MetricDescriptions.AccountCount
-	ch <- MetricDescriptions.ClusterAdminCount
-	ch <- MetricDescriptions.InitiatorCount
-	ch <- MetricDescriptions.VolumeAccessGroupCount
-	ch <- MetricDescriptions.VirtualVolumeTasks
-	ch <- MetricDescriptions.BulkVolumeJobs
-	ch <- MetricDescriptions.AsyncResultsActive
-	ch <- MetricDescriptions.AsyncResults
-	ch <- MetricDescriptions.MaxAsyncResultID
+	ch <- c.metrics.upDesc
+	ch <- c.metrics.APIVersionInfo
+	ch <- c.metrics.ScrapeCollectorDuration
+	ch <- c.metrics.ScrapeCollectorSuccess
+	ch <- c.metrics.InventoryObjects
+	ch <- c.metrics.InventoryChanges
+
+	ch <- c.metrics.VolumeActualIOPS
+	ch <- c.metrics.VolumeAverageIOPSizeBytes
+	ch <- c.metrics.VolumeBurstIOPSCredit
+	ch <- c.metrics.VolumeClientQueueDepth
+	ch <- c.metrics.VolumeLatencySeconds
+	ch <- c.metrics.VolumeNonZeroBlocks
+	ch <- c.metrics.VolumeReadBytesTotal
+	ch <- c.metrics.VolumeReadLatencySecondsTotal
+	ch <- c.metrics.VolumeReadOpsTotal
+	ch <- c.metrics.VolumeThrottle
+	ch <- c.metrics.VolumeUnalignedReadsTotal
+	ch <- c.metrics.VolumeUnalignedWritesTotal
+	ch <- c.metrics.VolumeSizeBytes
+	ch <- c.metrics.VolumeUtilization
+	ch <- c.metrics.VolumeWriteBytesTotal
+	ch <- c.metrics.VolumeWriteLatencyTotal
+	ch <- c.metrics.VolumeWriteOpsTotal
+	ch <- c.metrics.VolumeStatsZeroBlocks
+	ch <- c.metrics.VolumeInfo
+	ch <- c.metrics.VolumeCreatedTimestamp
+	ch <- c.metrics.VolumeLastIOTimestamp
+	ch <- c.metrics.VolumeProvisionedBytes
+	ch <- c.metrics.VolumeState
+	ch <- c.metrics.VolumePurgeTimestamp
+
+	ch <- c.metrics.VolumeSnapshots
+	ch <- c.metrics.VolumeSnapshotBytes
+	ch <- c.metrics.VolumeSnapshotsExpired
+	ch <- c.metrics.VolumeSnapshotOldestTimestamp
+	ch <- c.metrics.VolumeSnapshotNewestTimestamp
+	ch <- c.metrics.VolumeSnapshotRemoteStatus
+
+	ch <- c.metrics.VolumePairInfo
+	ch <- c.metrics.VolumePairState
+	ch <- c.metrics.VolumePairSnapshotReplicationState
+	ch <- c.metrics.VolumePairLastReplicatedSnapshotTimestamp
+	ch <- c.metrics.VolumeSnapMirrorReplicationEnabled
+
+	ch <- c.metrics.VolumeQoSMinIOPS
+	ch <- c.metrics.VolumeQoSMaxIOPS
+	ch <- c.metrics.VolumeQoSBurstIOPS
+	ch <- c.metrics.VolumeQoSBurstTimeSeconds
+	ch <- c.metrics.VolumeQoSCurve
+	ch <- c.metrics.VolumeQoSPolicyInfo
+
+	ch <- c.metrics.ClusterActiveBlockSpaceBytes
+	ch <- c.metrics.ClusterActiveSessions
+	ch <- c.metrics.ClusterAverageIOPS
+	ch <- c.metrics.ClusterClusterRecentIOSizeBytes
+	ch <- c.metrics.ClusterCurrentIOPS
+	ch <- c.metrics.ClusterMaxIOPS
+	ch <- c.metrics.ClusterMaxOverProvisionableSpaceBytes
+	ch <- c.metrics.ClusterMaxProvisionedSpaceBytes
+	ch <- c.metrics.ClusterMaxUsedMetadataSpaceBytes
+	ch <- c.metrics.ClusterMaxUsedSpaceBytes
+	ch <- c.metrics.ClusterNonZeroBlocks
+	ch <- c.metrics.ClusterPeakActiveSessions
+	ch <- c.metrics.ClusterPeakIOPS
+	ch <- c.metrics.ClusterProvisionedSpaceBytes
+	ch <- c.metrics.ClusterSnapshotNonZeroBlocks
+	ch <- c.metrics.ClusterIOPSTotal
+	ch <- c.metrics.ClusterUniqueBlocks
+	ch <- c.metrics.ClusterUniqueBlocksUsedSpaceBytes
+	ch <- c.metrics.ClusterUsedMetadataSpaceBytes
+	ch <- c.metrics.ClusterUsedMetadataSpaceInSnapshotsBytes
+	ch <- c.metrics.ClusterUsedSpaceBytes
+	ch <- c.metrics.ClusterZeroBlocks
+	ch <- c.metrics.ClusterThinProvisioningFactor
+	ch <- c.metrics.ClusterDeDuplicationFactor
+	ch <- c.metrics.ClusterCompressionFactor
+	ch <- c.metrics.ClusterEfficiencyFactor
+
+	ch <- c.metrics.ClusterActiveFaults
+
+	ch <- c.metrics.NodeSamples
+	ch <- c.metrics.NodeCPUPercentage
+	ch <- c.metrics.NodeCPUSecondsTotal
+	ch <- c.metrics.NodeInterfaceInBytesTotal
+	ch <- c.metrics.NodeInterfaceOutBytesTotal
+	ch <- c.metrics.NodeInterfaceUtilizationPercentage
+	ch <- c.metrics.NodeReadLatencyTotal
+	ch <- c.metrics.NodeUsedMemoryBytes
+	ch <- c.metrics.NodeWriteLatencyTotal
+	ch <- c.metrics.NodeLoadHistogram
+
+	ch <- c.metrics.NodeInfo
+
+	ch <- c.metrics.ClusterActualIOPS
+	ch <- c.metrics.ClusterAverageIOBytes
+	ch <- c.metrics.ClusterClientQueueDepth
+	ch <- c.metrics.ClusterThroughputUtilization
+	ch <- c.metrics.ClusterLatencySeconds
+	ch <- c.metrics.ClusterNormalizedIOPS
+	ch <- c.metrics.ClusterReadBytesTotal
+	ch <- c.metrics.ClusterLastSampleReadBytes
+	ch <- c.metrics.ClusterReadLatencySeconds
+	ch <- c.metrics.ClusterReadLatencyTotal
+	ch <- c.metrics.ClusterReadOpsTotal
+	ch <- c.metrics.ClusterLastSampleReadOps
+	ch <- c.metrics.ClusterSamplePeriodSeconds
+	ch <- c.metrics.ClusterServices
+	ch <- c.metrics.ClusterExpectedServices
+	ch <- c.metrics.ClusterUnalignedReadsTotal
+	ch <- c.metrics.ClusterUnalignedWritesTotal
+	ch <- c.metrics.ClusterWriteBytesTotal
+	ch <- c.metrics.ClusterLastSampleWriteBytes
+	ch <- c.metrics.ClusterWriteLatency
+	ch <- c.metrics.ClusterWriteLatencyTotal
+	ch <- c.metrics.ClusterWriteOpsTotal
+	ch <- c.metrics.ClusterLastSampleWriteOps
+
+	ch <- c.metrics.ClusterBlockFullness
+	ch <- c.metrics.ClusterFullness
+	ch <- c.metrics.ClusterMaxMetadataOverProvisionFactor
+	ch <- c.metrics.ClusterMetadataFullness
+	ch <- c.metrics.ClusterSliceReserveUsedThresholdPercentage
+	ch <- c.metrics.ClusterStage2AwareThresholdPercentage
+	ch <- c.metrics.ClusterStage2BlockThresholdBytes
+	ch <- c.metrics.ClusterStage3BlockThresholdBytes
+	ch <- c.metrics.ClusterStage3BlockThresholdPercentage
+	ch <- c.metrics.ClusterStage3LowThresholdPercentage
+	ch <- c.metrics.ClusterStage4BlockThresholdBytes
+	ch <- c.metrics.ClusterStage4CriticalThreshold
+	ch <- c.metrics.ClusterStage5BlockThresholdBytes
+	ch <- c.metrics.ClusterTotalBytes
+	ch <- c.metrics.ClusterTotalMetadataBytes
+	ch <- c.metrics.ClusterUsedBytes
+	ch <- c.metrics.ClusterUsedMetadataBytes
+
+	ch <- c.metrics.DriveStatus
+	ch <- c.metrics.DriveCapacityBytes
+
+	ch <- c.metrics.NodeISCSISessions
+
+	ch <- c.metrics.VolumeCount
+	ch <- c.metrics.DeletedVolumeCount
+	ch <- c.metrics.DeletedVolumeBytes
+	ch <- c.metrics.GroupSnapshotCount
+
+	ch <- c.metrics.SchedulePaused
+	ch <- c.metrics.ScheduleVolumes
+	ch <- c.metrics.ScheduleLastRunSuccess
+	ch <- c.metrics.ScheduleLastRunTimestamp
+	ch <- c.metrics.ScheduleNextRunTimestamp
+
+	ch <- c.metrics.ClusterPairStatus
+	ch <- c.metrics.ClusterPairLatencySeconds
+
+	ch <- c.metrics.SnapMirrorEndpointConnected
+	ch <- c.metrics.SnapMirrorRelationshipMirrorState
+	ch <- c.metrics.SnapMirrorRelationshipStatus
+	ch <- c.metrics.SnapMirrorRelationshipHealthy
+	ch <- c.metrics.SnapMirrorRelationshipLagSeconds
+	ch <- c.metrics.SnapMirrorRelationshipLastTransferBytes
+	ch <- c.metrics.SnapMirrorRelationshipLastTransferDurationSeconds
+
+	ch <- c.metrics.ClusterInfo
+	ch <- c.metrics.ClusterVersionInfo
+	ch <- c.metrics.ClusterUpgradePending
+	ch <- c.metrics.ClusterNodeVersions
+	ch <- c.metrics.NodeVersionInfo
+
+	ch <- c.metrics.NodeClusterMaster
+	ch <- c.metrics.NodeEnsembleMember
+	ch <- c.metrics.ClusterEnsembleSize
+	ch <- c.metrics.ClusterMasterChanges
+
+	ch <- c.metrics.NodeFanSpeedRPM
+	ch <- c.metrics.NodeTemperatureCelsius
+	ch <- c.metrics.NodePowerSupplyStatus
+	ch <- c.metrics.NodeHardwareInfo
+
+	ch <- c.metrics.AccountCount
+	ch <- c.metrics.ClusterAdminCount
+	ch <- c.metrics.InitiatorCount
+	ch <- c.metrics.VolumeAccessGroupCount
+	ch <- c.metrics.VirtualVolumeTasks
+	ch <- c.metrics.BulkVolumeJobs
+	ch <- c.metrics.AsyncResultsActive
+	ch <- c.metrics.AsyncResults
+	ch <- c.metrics.MaxAsyncResultID
 }
 
 func (c *SolidfireCollector) collectVolumeMeta(ctx context.Context, ch chan<- prometheus.Metric) error {
-	volumes, err := c.client.ListVolumes(ctx)
+	volumes, metadataByID, err := c.listVolumeMeta(ctx, false)
 	if err != nil {
 		return err
 	}
-	mu.Lock()
-	defer mu.Unlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.volumes.replace(metadataByID, time.Now())
+	c.volumes.collect(ch)
 
-	volumeCntByStatus := map[string]int{}
+	for _, metadata := range metadataByID {
+		if !c.volumeFilter.match(metadata) {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			c.metrics.VolumeInfo,
+			prometheus.GaugeValue,
+			1,
+			metadata.VolumeId,
+			metadata.Name,
+			strconv.Itoa(metadata.AccountID),
+			metadata.AccountName,
+			metadata.Status,
+			metadata.Access,
+			strconv.Itoa(metadata.BlockSize),
+			strconv.FormatBool(metadata.Enable512E),
+			metadata.Iqn,
+			metadata.ScsiNAADeviceID,
+			metadata.ScsiEUIDeviceID,
+			metadata.VolumeUUID,
+			metadata.VolumeConsistencyGroupUUID,
+			metadata.ProtectionScheme,
+			strconv.Itoa(metadata.SliceCount),
+			idsLabelValue(metadata.VolumeAccessGroupIDs),
+			attributesLabelValue(metadata.Attributes),
+		)
 
-	for _, vol := range volumes.Result.Volumes {
-		metadata := volumeMetadata{
-			Name:     vol.Name,
-			VolumeId: strconv.Itoa(vol.VolumeID),
+		values := c.volumeLabelValues(metadata)
+		ch <- prometheus.MustNewConstMetric(
+			c.metrics.VolumeProvisionedBytes,
+			prometheus.GaugeValue,
+			float64(metadata.TotalSize),
+			values...)
+
+		if !metadata.CreateTime.IsZero() {
+			ch <- prometheus.MustNewConstMetric(
+				c.metrics.VolumeCreatedTimestamp,
+				prometheus.GaugeValue,
+				float64(metadata.CreateTime.Unix()),
+				values...)
 		}
-		ownerId, ok := vol.Attributes["owner_id"]
-		if ok {
-			metadata.AccountId = ownerId
+
+		if !metadata.LastAccessTimeIO.IsZero() {
+			ch <- prometheus.MustNewConstMetric(
+				c.metrics.VolumeLastIOTimestamp,
+				prometheus.GaugeValue,
+				float64(metadata.LastAccessTimeIO.Unix()),
+				values...)
 		}
-		c.volumeMetadataByID[vol.VolumeID] = metadata
+	}
+
+	volumeCntByStatus := map[string]int{}
+	for _, vol := range volumes.Result.Volumes {
 		volumeCntByStatus[vol.Status]++
 	}
 
 	for status, count := range volumeCntByStatus {
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.VolumeCount,
+			c.metrics.VolumeCount,
 			prometheus.CounterValue,
 			float64(count),
 			status,
@@ -220,17 +398,254 @@ func (c *SolidfireCollector) collectVolumeMeta(ctx context.Context, ch chan<- pr
 	return nil
 }
 
+// listVolumeMeta lists the volumes and builds their metadata. Account names
+// are only required when the volume filter or labels use them, otherwise a
+// failed lookup leaves them empty. Volume access group names are only looked
+// up when the volume filter matches on them. With forceRefresh the volume list
+// bypasses any response cache.
+func (c *SolidfireCollector) listVolumeMeta(ctx context.Context, forceRefresh bool) (solidfire.ListVolumesResponse, map[int]volumeMetadata, error) {
+	volumesCtx := ctx
+	if forceRefresh {
+		volumesCtx = solidfire.WithForceRefresh(ctx)
+	}
+	volumes, err := c.client.ListVolumes(volumesCtx)
+	if err != nil {
+		return volumes, nil, err
+	}
+	accountNames, err := c.accountNames(ctx)
+	if err != nil {
+		return volumes, nil, err
+	}
+	groupNames := map[int]string{}
+	if c.volumeFilter.needsVolumeAccessGroupNames() {
+		groups, err := c.client.ListVolumeAccessGroups(ctx)
+		if err != nil {
+			return volumes, nil, fmt.Errorf("Error looking up volume access group names: %w", err)
+		}
+		for _, group := range groups.Result.VolumeAccessGroups {
+			groupNames[group.VolumeAccessGroupID] = group.Name
+		}
+	}
+
+	metadataByID := make(map[int]volumeMetadata, len(volumes.Result.Volumes))
+	for _, vol := range volumes.Result.Volumes {
+		metadata := volumeMetadata{
+			Name:                       vol.Name,
+			VolumeId:                   strconv.Itoa(vol.VolumeID),
+			Status:                     vol.Status,
+			AccountID:                  vol.AccountID,
+			AccountName:                accountNames[vol.AccountID],
+			VolumeAccessGroupIDs:       vol.VolumeAccessGroups,
+			Attributes:                 vol.Attributes,
+			Access:                     vol.Access,
+			BlockSize:                  vol.BlockSize,
+			Enable512E:                 vol.Enable512E,
+			Iqn:                        vol.Iqn,
+			ScsiNAADeviceID:            vol.ScsiNAADeviceID,
+			ScsiEUIDeviceID:            vol.ScsiEUIDeviceID,
+			VolumeUUID:                 vol.VolumeUUID,
+			VolumeConsistencyGroupUUID: vol.VolumeConsistencyGroupUUID,
+			ProtectionScheme:           vol.CurrentProtectionScheme,
+			SliceCount:                 vol.SliceCount,
+			CreateTime:                 vol.CreateTime,
+			LastAccessTimeIO:           vol.LastAccessTimeIO,
+			TotalSize:                  vol.TotalSize,
+			QoS: volumeQoS{
+				MinIOPS:   vol.Qos.MinIOPS,
+				MaxIOPS:   vol.Qos.MaxIOPS,
+				BurstIOPS: vol.Qos.BurstIOPS,
+				BurstTime: vol.Qos.BurstTime,
+				Curve: map[int]int{
+					4096:    vol.Qos.Curve.Num4096,
+					8192:    vol.Qos.Curve.Num8192,
+					16384:   vol.Qos.Curve.Num16384,
+					32768:   vol.Qos.Curve.Num32768,
+					65536:   vol.Qos.Curve.Num65536,
+					131072:  vol.Qos.Curve.Num131072,
+					262144:  vol.Qos.Curve.Num262144,
+					524288:  vol.Qos.Curve.Num524288,
+					1048576: vol.Qos.Curve.Num1048576,
+				},
+			},
+		}
+		ownerId, ok := vol.Attributes["owner_id"]
+		if ok {
+			metadata.OwnerId = ownerId
+		}
+		metadata.EnableSnapMirrorReplication = vol.EnableSnapMirrorReplication
+		for _, id := range vol.VolumeAccessGroups {
+			if name, ok := groupNames[id]; ok {
+				metadata.VolumeAccessGroupNames = append(metadata.VolumeAccessGroupNames, name)
+			}
+		}
+		metadataByID[vol.VolumeID] = metadata
+	}
+	return volumes, metadataByID, nil
+}
+
+// accountNames maps account IDs to names, for solidfire_volume_info and, when
+// enabled, the volume filter and labels. ListAccounts is shared with the
+// accounts collector. A failed lookup is only an error when the filter or
+// labels need the names; otherwise the names are left empty.
+func (c *SolidfireCollector) accountNames(ctx context.Context) (map[int]string, error) {
+	accountNames := map[int]string{}
+	accounts, err := c.listAccounts(ctx)
+	if err != nil {
+		if c.volumeFilter.needsAccountNames() || c.volumeLabels.AccountName {
+			return nil, fmt.Errorf("Error looking up account names: %w", err)
+		}
+		log.Warningf("could not look up account names: %v", err)
+		return accountNames, nil
+	}
+	for _, account := range accounts.Result.Accounts {
+		accountNames[account.AccountID] = account.Username
+	}
+	return accountNames, nil
+}
+
+// listAccounts lists the accounts once per scrape for the account names and
+// the accounts collector.
+func (c *SolidfireCollector) listAccounts(ctx context.Context) (solidfire.ListAccountsResponse, error) {
+	return shared(ctx, string(solidfire.RPCListAccounts), func() (solidfire.ListAccountsResponse, error) {
+		return c.client.ListAccounts(ctx)
+	})
+}
+
+// deletedVolumeMeta is the deleted volume listing with the metadata built
+// from it.
+type deletedVolumeMeta struct {
+	volumes  solidfire.ListDeletedVolumesResponse
+	metadata map[int]volumeMetadata
+}
+
+// listDeletedVolumeMeta lists the deleted volumes awaiting purge and builds
+// their metadata, once per scrape for the deleted_volumes and volume_state
+// collectors. Deleted volumes are not in the volume inventory.
+func (c *SolidfireCollector) listDeletedVolumeMeta(ctx context.Context) (solidfire.ListDeletedVolumesResponse, map[int]volumeMetadata, error) {
+	deleted, err := shared(ctx, string(solidfire.RPCListDeletedVolumes), func() (deletedVolumeMeta, error) {
+		volumes, metadata, err := c.buildDeletedVolumeMeta(ctx)
+		return deletedVolumeMeta{volumes: volumes, metadata: metadata}, err
+	})
+	return deleted.volumes, deleted.metadata, err
+}
+
+func (c *SolidfireCollector) buildDeletedVolumeMeta(ctx context.Context) (solidfire.ListDeletedVolumesResponse, map[int]volumeMetadata, error) {
+	volumes, err := c.client.ListDeletedVolumes(ctx)
+	if err != nil {
+		return volumes, nil, err
+	}
+	accountNames, err := c.accountNames(ctx)
+	if err != nil {
+		return volumes, nil, err
+	}
+	metadataByID := make(map[int]volumeMetadata, len(volumes.Result.Volumes))
+	for _, vol := range volumes.Result.Volumes {
+		metadata := volumeMetadata{
+			Name:                 vol.Name,
+			VolumeId:             strconv.Itoa(vol.VolumeID),
+			OwnerId:              vol.Attributes["owner_id"],
+			Status:               vol.Status,
+			AccountID:            vol.AccountID,
+			AccountName:          accountNames[vol.AccountID],
+			VolumeAccessGroupIDs: vol.VolumeAccessGroups,
+			Attributes:           vol.Attributes,
+			CreateTime:           vol.CreateTime,
+			TotalSize:            vol.TotalSize,
+		}
+		if purgeTime, err := time.Parse(time.RFC3339, vol.PurgeTime); err == nil {
+			metadata.PurgeTime = purgeTime
+		}
+		metadataByID[vol.VolumeID] = metadata
+	}
+	return volumes, metadataByID, nil
+}
+
+func (c *SolidfireCollector) collectDeletedVolumes(ctx context.Context, ch chan<- prometheus.Metric) error {
+	volumes, metadataByID, err := c.listDeletedVolumeMeta(ctx)
+	if err != nil {
+		return err
+	}
+	var totalSize int64
+	for _, vol := range volumes.Result.Volumes {
+		totalSize += vol.TotalSize
+	}
+	ch <- prometheus.MustNewConstMetric(
+		c.metrics.DeletedVolumeCount,
+		prometheus.GaugeValue,
+		float64(len(volumes.Result.Volumes)),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.metrics.DeletedVolumeBytes,
+		prometheus.GaugeValue,
+		float64(totalSize),
+	)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, metadata := range metadataByID {
+		if !c.volumeFilter.match(metadata) || metadata.PurgeTime.IsZero() {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			c.metrics.VolumePurgeTimestamp,
+			prometheus.GaugeValue,
+			float64(metadata.PurgeTime.Unix()),
+			c.volumeLabelValues(metadata)...)
+	}
+	return nil
+}
+
+// refreshVolumeMeta re-reads the volume list, bypassing any response cache,
+// when volumeIDs contains a volume the metadata does not know yet. The list is
+// re-read at most once per scrape, however many collectors find unknown
+// volumes, so a failing ListVolumes is not retried by each of them.
+func (c *SolidfireCollector) refreshVolumeMeta(ctx context.Context, volumeIDs []int) {
+	c.mu.Lock()
+	unknown := false
+	for _, id := range volumeIDs {
+		if _, ok := c.volumes.get(id); !ok {
+			unknown = true
+			break
+		}
+	}
+	c.mu.Unlock()
+	if !unknown {
+		return
+	}
+	shared(ctx, "refreshVolumeMeta", func() (struct{}, error) {
+		_, metadataByID, err := c.listVolumeMeta(ctx, true)
+		if err != nil {
+			log.Warningf("could not refresh volume metadata: %v", err)
+			return struct{}{}, err
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.volumes.replace(metadataByID, time.Now())
+		return struct{}{}, nil
+	})
+}
+
+// listAllNodes lists the nodes once per scrape for node_meta and the
+// collectors that need more than the node names, such as ensemble and
+// hardware.
+func (c *SolidfireCollector) listAllNodes(ctx context.Context) (solidfire.ListAllNodesResponse, error) {
+	return shared(ctx, string(solidfire.RPCListAllNodes), func() (solidfire.ListAllNodesResponse, error) {
+		return c.client.ListAllNodes(ctx)
+	})
+}
+
 func (c *SolidfireCollector) collectNodeMeta(ctx context.Context, ch chan<- prometheus.Metric) error {
-	nodes, err := c.client.ListAllNodes(ctx)
+	nodes, err := c.listAllNodes(ctx)
 	if err != nil {
 		return err
 	}
-	mu.Lock()
-	defer mu.Unlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	namesByID := make(map[int]string, len(nodes.Result.Nodes))
 	for _, node := range nodes.Result.Nodes {
-		c.nodesNamesByID[node.NodeID] = node.Name
+		namesByID[node.NodeID] = node.Name
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.NodeInfo,
+			c.metrics.NodeInfo,
 			prometheus.GaugeValue,
 			1,
 			strconv.Itoa(node.NodeID),
@@ -248,135 +663,181 @@ func (c *SolidfireCollector) collectNodeMeta(ctx context.Context, ch chan<- prom
 			node.UUID,
 		)
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.NodeTotalMemoryBytes,
+			c.metrics.NodeTotalMemoryBytes,
 			prometheus.GaugeValue,
 			GigabytesToBytes(node.PlatformInfo.NodeMemoryGB),
 			strconv.Itoa(node.NodeID),
 			node.Name,
 		)
 	}
+	c.nodes.replace(namesByID, time.Now())
+	c.nodes.collect(ch)
 	return nil
 }
 
+// reportedVolume returns the metadata of a volume and whether the volume
+// filter selects it. Callers hold c.mu.
+func (c *SolidfireCollector) reportedVolume(volumeID int) (volumeMetadata, bool) {
+	metadata := c.volumeMetadata(volumeID)
+	return metadata, c.volumeFilter.match(metadata)
+}
+
+// reportedVolumeNamed is reportedVolume for responses that carry the volume
+// name. The name is used for volumes missing from the volume listing, e.g.
+// deleted volumes, before the filter is evaluated. Callers hold c.mu.
+func (c *SolidfireCollector) reportedVolumeNamed(volumeID int, name string) (volumeMetadata, bool) {
+	metadata := c.volumeMetadata(volumeID)
+	if metadata.Name == "" {
+		metadata.Name = name
+	}
+	return metadata, c.volumeFilter.match(metadata)
+}
+
+// volumeLabelValues returns the label values of a per-volume metric.
+func (c *SolidfireCollector) volumeLabelValues(metadata volumeMetadata) []string {
+	return append(metadata.Values(), c.volumeLabels.labelValues(metadata)...)
+}
+
+// volumeMetadata returns the metadata of a volume from the latest volume
+// listing. Volumes missing from it, e.g. because ListVolumes has not succeeded
+// yet, only carry their ID. Callers hold c.mu.
+func (c *SolidfireCollector) volumeMetadata(volumeID int) volumeMetadata {
+	if metadata, ok := c.volumes.get(volumeID); ok {
+		return metadata
+	}
+	return volumeMetadata{VolumeId: strconv.Itoa(volumeID)}
+}
+
+// nodeName returns the name of a node from the latest node listing, or an
+// empty string for unknown nodes. Callers hold c.mu.
+func (c *SolidfireCollector) nodeName(nodeID int) string {
+	name, _ := c.nodes.get(nodeID)
+	return name
+}
+
 func (c *SolidfireCollector) collectVolumeStats(ctx context.Context, ch chan<- prometheus.Metric) error {
 	volumeStats, err := c.client.ListVolumeStats(ctx)
 	if err != nil {
 		return err
 	}
-	mu.Lock()
-	defer mu.Unlock()
+	volumeIDs := make([]int, 0, len(volumeStats.Result.VolumeStats))
 	for _, vol := range volumeStats.Result.VolumeStats {
-		metadata := c.volumeMetadataByID[vol.VolumeID]
-		name := metadata.Name
-		values := metadata.Values()
-
-		if ok, _ := regexp.MatchString(`snapshot-clone-src-*|replica-vol-*`, name); ok {
+		volumeIDs = append(volumeIDs, vol.VolumeID)
+	}
+	c.refreshVolumeMeta(ctx, volumeIDs)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, vol := range volumeStats.Result.VolumeStats {
+		metadata, ok := c.reportedVolume(vol.VolumeID)
+		if !ok {
 			continue
 		}
+		values := c.volumeLabelValues(metadata)
+
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.VolumeActualIOPS,
+			c.metrics.VolumeActualIOPS,
 			prometheus.GaugeValue,
 			vol.ActualIOPS,
 			values...)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.VolumeAverageIOPSizeBytes,
+			c.metrics.VolumeAverageIOPSizeBytes,
 			prometheus.GaugeValue,
 			vol.AverageIOPSize,
 			values...)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.VolumeBurstIOPSCredit,
+			c.metrics.VolumeBurstIOPSCredit,
 			prometheus.GaugeValue,
 			vol.BurstIOPSCredit,
 			values...)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.VolumeClientQueueDepth,
+			c.metrics.VolumeClientQueueDepth,
 			prometheus.GaugeValue,
 			vol.ClientQueueDepth,
 			values...)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.VolumeLatencySeconds,
+			c.metrics.VolumeLatencySeconds,
 			prometheus.GaugeValue,
 			MicrosecondsToSeconds(vol.LatencyUSec),
 			values...)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.VolumeNonZeroBlocks,
+			c.metrics.VolumeNonZeroBlocks,
 			prometheus.GaugeValue,
 			vol.NonZeroBlocks,
 			values...)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.VolumeReadBytesTotal,
+			c.metrics.VolumeReadBytesTotal,
 			prometheus.CounterValue,
 			vol.ReadBytes,
 			values...)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.VolumeReadLatencySecondsTotal,
+			c.metrics.VolumeReadLatencySecondsTotal,
 			prometheus.CounterValue,
 			MicrosecondsToSeconds(vol.ReadLatencyUSecTotal),
 			values...)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.VolumeReadOpsTotal,
+			c.metrics.VolumeReadOpsTotal,
 			prometheus.CounterValue,
 			vol.ReadOps,
 			values...)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.VolumeThrottle,
+			c.metrics.VolumeThrottle,
 			prometheus.GaugeValue,
 			vol.Throttle,
 			values...)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.VolumeUnalignedReadsTotal,
+			c.metrics.VolumeUnalignedReadsTotal,
 			prometheus.CounterValue,
 			vol.UnalignedReads,
 			values...)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.VolumeUnalignedWritesTotal,
+			c.metrics.VolumeUnalignedWritesTotal,
 			prometheus.CounterValue,
 			vol.UnalignedWrites,
 			values...)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.VolumeSizeBytes,
+			c.metrics.VolumeSizeBytes,
 			prometheus.GaugeValue,
 			vol.VolumeSize,
 			values...)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.VolumeUtilization,
+			c.metrics.VolumeUtilization,
 			prometheus.GaugeValue,
 			vol.VolumeUtilization,
 			values...)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.VolumeWriteBytesTotal,
+			c.metrics.VolumeWriteBytesTotal,
 			prometheus.CounterValue,
 			vol.WriteBytes,
 			values...)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.VolumeWriteLatencyTotal,
+			c.metrics.VolumeWriteLatencyTotal,
 			prometheus.CounterValue,
 			MicrosecondsToSeconds(vol.WriteLatencyUSecTotal),
 			values...)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.VolumeWriteOpsTotal,
+			c.metrics.VolumeWriteOpsTotal,
 			prometheus.CounterValue,
 			vol.WriteOps,
 			values...)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.VolumeStatsZeroBlocks,
+			c.metrics.VolumeStatsZeroBlocks,
 			prometheus.GaugeValue,
 			vol.ZeroBlocks,
 			values...)
@@ -384,6 +845,74 @@ func (c *SolidfireCollector) collectVolumeStats(ctx context.Context, ch chan<- p
 	return nil
 }
 
+// collectVolumeQoS reports the QoS settings of the volumes from the latest
+// volume listing, and the QoS policy of each volume. The settings don't need
+// ListQoSPolicies, which older Element versions or restricted API users may
+// lack, so they are reported even when it fails.
+func (c *SolidfireCollector) collectVolumeQoS(ctx context.Context, ch chan<- prometheus.Metric) error {
+	policies, policiesErr := c.client.ListQoSPolicies(ctx)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	volumes, ok := c.volumes.values()
+	if !ok {
+		return fmt.Errorf("no volume listing available")
+	}
+	for _, metadata := range volumes {
+		if !c.volumeFilter.match(metadata) {
+			continue
+		}
+		values := c.volumeLabelValues(metadata)
+		ch <- prometheus.MustNewConstMetric(
+			c.metrics.VolumeQoSMinIOPS,
+			prometheus.GaugeValue,
+			float64(metadata.QoS.MinIOPS),
+			values...)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.metrics.VolumeQoSMaxIOPS,
+			prometheus.GaugeValue,
+			float64(metadata.QoS.MaxIOPS),
+			values...)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.metrics.VolumeQoSBurstIOPS,
+			prometheus.GaugeValue,
+			float64(metadata.QoS.BurstIOPS),
+			values...)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.metrics.VolumeQoSBurstTimeSeconds,
+			prometheus.GaugeValue,
+			float64(metadata.QoS.BurstTime),
+			values...)
+
+		for size, cost := range metadata.QoS.Curve {
+			ch <- prometheus.MustNewConstMetric(
+				c.metrics.VolumeQoSCurve,
+				prometheus.GaugeValue,
+				float64(cost),
+				append(values, strconv.Itoa(size))...)
+		}
+	}
+	if policiesErr != nil {
+		return policiesErr
+	}
+	for _, policy := range policies.Result.QoSPolicies {
+		for _, id := range policy.VolumeIDs {
+			metadata, ok := volumes[id]
+			if !ok || !c.volumeFilter.match(metadata) {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(
+				c.metrics.VolumeQoSPolicyInfo,
+				prometheus.GaugeValue,
+				1,
+				append(c.volumeLabelValues(metadata), strconv.Itoa(policy.QoSPolicyID), policy.Name)...)
+		}
+	}
+	return nil
+}
+
 func (c *SolidfireCollector) collectClusterCapacity(ctx context.Context, ch chan<- prometheus.Metric) error {
 	clusterCapacity, err := c.client.GetClusterCapacity(ctx)
 	if err != nil {
@@ -391,112 +920,112 @@ func (c *SolidfireCollector) collectClusterCapacity(ctx context.Context, ch chan
 	}
 	cluster := clusterCapacity.Result.ClusterCapacity
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterActiveBlockSpaceBytes,
+		c.metrics.ClusterActiveBlockSpaceBytes,
 		prometheus.GaugeValue,
 		cluster.ActiveBlockSpace)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterActiveSessions,
+		c.metrics.ClusterActiveSessions,
 		prometheus.GaugeValue,
 		cluster.ActiveSessions)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterAverageIOPS,
+		c.metrics.ClusterAverageIOPS,
 		prometheus.GaugeValue,
 		cluster.AverageIOPS)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterClusterRecentIOSizeBytes,
+		c.metrics.ClusterClusterRecentIOSizeBytes,
 		prometheus.GaugeValue,
 		cluster.ClusterRecentIOSize)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterCurrentIOPS,
+		c.metrics.ClusterCurrentIOPS,
 		prometheus.GaugeValue,
 		cluster.CurrentIOPS)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterMaxIOPS,
+		c.metrics.ClusterMaxIOPS,
 		prometheus.GaugeValue,
 		cluster.MaxIOPS)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterMaxOverProvisionableSpaceBytes,
+		c.metrics.ClusterMaxOverProvisionableSpaceBytes,
 		prometheus.GaugeValue,
 		cluster.MaxOverProvisionableSpace)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterMaxProvisionedSpaceBytes,
+		c.metrics.ClusterMaxProvisionedSpaceBytes,
 		prometheus.GaugeValue,
 		cluster.MaxProvisionedSpace)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterMaxUsedMetadataSpaceBytes,
+		c.metrics.ClusterMaxUsedMetadataSpaceBytes,
 		prometheus.GaugeValue,
 		cluster.MaxUsedMetadataSpace)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterMaxUsedSpaceBytes,
+		c.metrics.ClusterMaxUsedSpaceBytes,
 		prometheus.GaugeValue,
 		cluster.MaxUsedSpace)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterNonZeroBlocks,
+		c.metrics.ClusterNonZeroBlocks,
 		prometheus.GaugeValue,
 		cluster.NonZeroBlocks)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterPeakActiveSessions,
+		c.metrics.ClusterPeakActiveSessions,
 		prometheus.GaugeValue,
 		cluster.PeakActiveSessions)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterPeakIOPS,
+		c.metrics.ClusterPeakIOPS,
 		prometheus.GaugeValue,
 		cluster.PeakIOPS)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterProvisionedSpaceBytes,
+		c.metrics.ClusterProvisionedSpaceBytes,
 		prometheus.GaugeValue,
 		cluster.ProvisionedSpace)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterSnapshotNonZeroBlocks,
+		c.metrics.ClusterSnapshotNonZeroBlocks,
 		prometheus.GaugeValue,
 		cluster.SnapshotNonZeroBlocks)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterIOPSTotal,
+		c.metrics.ClusterIOPSTotal,
 		prometheus.CounterValue,
 		cluster.TotalOps)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterUniqueBlocks,
+		c.metrics.ClusterUniqueBlocks,
 		prometheus.GaugeValue,
 		cluster.UniqueBlocks)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterUniqueBlocksUsedSpaceBytes,
+		c.metrics.ClusterUniqueBlocksUsedSpaceBytes,
 		prometheus.GaugeValue,
 		cluster.UniqueBlocksUsedSpace)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterUsedMetadataSpaceBytes,
+		c.metrics.ClusterUsedMetadataSpaceBytes,
 		prometheus.GaugeValue,
 		cluster.UsedMetadataSpace)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterUsedMetadataSpaceInSnapshotsBytes,
+		c.metrics.ClusterUsedMetadataSpaceInSnapshotsBytes,
 		prometheus.GaugeValue,
 		cluster.UsedMetadataSpaceInSnapshots)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterUsedSpaceBytes,
+		c.metrics.ClusterUsedSpaceBytes,
 		prometheus.GaugeValue,
 		cluster.UsedSpace)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterZeroBlocks,
+		c.metrics.ClusterZeroBlocks,
 		prometheus.GaugeValue,
 		cluster.ZeroBlocks)
 
@@ -506,7 +1035,7 @@ func (c *SolidfireCollector) collectClusterCapacity(ctx context.Context, ch chan
 	}
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterThinProvisioningFactor,
+		c.metrics.ClusterThinProvisioningFactor,
 		prometheus.GaugeValue,
 		clusterThinProvisioningFactor)
 
@@ -516,7 +1045,7 @@ func (c *SolidfireCollector) collectClusterCapacity(ctx context.Context, ch chan
 	}
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterDeDuplicationFactor,
+		c.metrics.ClusterDeDuplicationFactor,
 		prometheus.GaugeValue,
 		clusterDeDuplicationFactor)
 
@@ -526,12 +1055,12 @@ func (c *SolidfireCollector) collectClusterCapacity(ctx context.Context, ch chan
 	}
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterCompressionFactor,
+		c.metrics.ClusterCompressionFactor,
 		prometheus.GaugeValue,
 		clusterCompressionFactor)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterEfficiencyFactor,
+		c.metrics.ClusterEfficiencyFactor,
 		prometheus.GaugeValue,
 		clusterThinProvisioningFactor*clusterDeDuplicationFactor*clusterCompressionFactor)
 	return nil
@@ -542,15 +1071,15 @@ func (c *SolidfireCollector) collectClusterFaults(ctx context.Context, ch chan<-
 	if err != nil {
 		return err
 	}
-	mu.Lock()
-	defer mu.Unlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	for _, f := range ClusterActiveFaults.Result.Faults {
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.ClusterActiveFaults,
+			c.metrics.ClusterActiveFaults,
 			prometheus.GaugeValue,
 			1,
 			strconv.Itoa(f.NodeID),
-			c.nodesNamesByID[f.NodeID],
+			c.nodeName(f.NodeID),
 			f.Code,
 			f.Severity,
 			f.Type,
@@ -569,8 +1098,8 @@ func (c *SolidfireCollector) collectClusterNodeStats(ctx context.Context, ch cha
 	if err != nil {
 		return err
 	}
-	mu.Lock()
-	defer mu.Unlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	for _, stats := range ClusterNodeStats.Result.NodeStats.Nodes {
 		SsLoadHistogram := map[float64]uint64{
 			0:   stats.SsLoadHistogram.Bucket0,
@@ -582,132 +1111,132 @@ func (c *SolidfireCollector) collectClusterNodeStats(ctx context.Context, ch cha
 		}
 
 		ch <- prometheus.MustNewConstHistogram(
-			MetricDescriptions.NodeLoadHistogram,
+			c.metrics.NodeLoadHistogram,
 			stats.Count,
 			float64(sumHistogram(SsLoadHistogram)),
 			SsLoadHistogram,
 			strconv.Itoa(stats.NodeID),
-			c.nodesNamesByID[stats.NodeID],
+			c.nodeName(stats.NodeID),
 		)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.NodeInterfaceInBytesTotal,
+			c.metrics.NodeInterfaceInBytesTotal,
 			prometheus.CounterValue,
 			stats.CBytesIn,
 			strconv.Itoa(stats.NodeID),
-			c.nodesNamesByID[stats.NodeID],
+			c.nodeName(stats.NodeID),
 			"cluster",
 		)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.NodeInterfaceOutBytesTotal,
+			c.metrics.NodeInterfaceOutBytesTotal,
 			prometheus.CounterValue,
 			stats.CBytesOut,
 			strconv.Itoa(stats.NodeID),
-			c.nodesNamesByID[stats.NodeID],
+			c.nodeName(stats.NodeID),
 			"cluster",
 		)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.NodeSamples,
+			c.metrics.NodeSamples,
 			prometheus.GaugeValue,
 			float64(stats.Count),
 			strconv.Itoa(stats.NodeID),
-			c.nodesNamesByID[stats.NodeID],
+			c.nodeName(stats.NodeID),
 		)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.NodeCPUPercentage,
+			c.metrics.NodeCPUPercentage,
 			prometheus.GaugeValue,
 			stats.CPU,
 			strconv.Itoa(stats.NodeID),
-			c.nodesNamesByID[stats.NodeID],
+			c.nodeName(stats.NodeID),
 		)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.NodeCPUSecondsTotal,
+			c.metrics.NodeCPUSecondsTotal,
 			prometheus.CounterValue,
 			stats.CPUTotal,
 			strconv.Itoa(stats.NodeID),
-			c.nodesNamesByID[stats.NodeID],
+			c.nodeName(stats.NodeID),
 		)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.NodeInterfaceInBytesTotal,
+			c.metrics.NodeInterfaceInBytesTotal,
 			prometheus.CounterValue,
 			stats.MBytesIn,
 			strconv.Itoa(stats.NodeID),
-			c.nodesNamesByID[stats.NodeID],
+			c.nodeName(stats.NodeID),
 			"management",
 		)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.NodeInterfaceOutBytesTotal,
+			c.metrics.NodeInterfaceOutBytesTotal,
 			prometheus.CounterValue,
 			stats.MBytesOut,
 			strconv.Itoa(stats.NodeID),
-			c.nodesNamesByID[stats.NodeID],
+			c.nodeName(stats.NodeID),
 			"management",
 		)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.NodeInterfaceUtilizationPercentage,
+			c.metrics.NodeInterfaceUtilizationPercentage,
 			prometheus.GaugeValue,
 			stats.NetworkUtilizationCluster,
 			strconv.Itoa(stats.NodeID),
-			c.nodesNamesByID[stats.NodeID],
+			c.nodeName(stats.NodeID),
 			"cluster",
 		)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.NodeInterfaceUtilizationPercentage,
+			c.metrics.NodeInterfaceUtilizationPercentage,
 			prometheus.GaugeValue,
 			stats.NetworkUtilizationStorage,
 			strconv.Itoa(stats.NodeID),
-			c.nodesNamesByID[stats.NodeID],
+			c.nodeName(stats.NodeID),
 			"storage",
 		)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.NodeReadLatencyTotal,
+			c.metrics.NodeReadLatencyTotal,
 			prometheus.CounterValue,
 			MicrosecondsToSeconds(stats.ReadLatencyUSecTotal),
 			strconv.Itoa(stats.NodeID),
-			c.nodesNamesByID[stats.NodeID],
+			c.nodeName(stats.NodeID),
 		)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.NodeInterfaceInBytesTotal,
+			c.metrics.NodeInterfaceInBytesTotal,
 			prometheus.CounterValue,
 			stats.SBytesIn,
 			strconv.Itoa(stats.NodeID),
-			c.nodesNamesByID[stats.NodeID],
+			c.nodeName(stats.NodeID),
 			"storage",
 		)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.NodeInterfaceOutBytesTotal,
+			c.metrics.NodeInterfaceOutBytesTotal,
 			prometheus.CounterValue,
 			stats.SBytesOut,
 			strconv.Itoa(stats.NodeID),
-			c.nodesNamesByID[stats.NodeID],
+			c.nodeName(stats.NodeID),
 			"storage",
 		)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.NodeUsedMemoryBytes,
+			c.metrics.NodeUsedMemoryBytes,
 			prometheus.GaugeValue,
 			stats.UsedMemory,
 			strconv.Itoa(stats.NodeID),
-			c.nodesNamesByID[stats.NodeID],
+			c.nodeName(stats.NodeID),
 		)
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.NodeWriteLatencyTotal,
+			c.metrics.NodeWriteLatencyTotal,
 			prometheus.CounterValue,
 			MicrosecondsToSeconds(stats.WriteLatencyUSecTotal),
 			strconv.Itoa(stats.NodeID),
-			c.nodesNamesByID[stats.NodeID],
+			c.nodeName(stats.NodeID),
 		)
 
 	}
@@ -719,15 +1248,19 @@ func (c *SolidfireCollector) collectVolumeQosHistograms(ctx context.Context, ch
 	if err != nil {
 		return err
 	}
-	mu.Lock()
-	defer mu.Unlock()
+	volumeIDs := make([]int, 0, len(VolumeQoSHistograms.Result.QosHistograms))
 	for _, h := range VolumeQoSHistograms.Result.QosHistograms {
-		metadata := c.volumeMetadataByID[h.VolumeID]
-		name := metadata.Name
-		values := metadata.Values()
-		if ok, _ := regexp.MatchString(`snapshot-clone-src-*|replica-vol-*`, name); ok {
+		volumeIDs = append(volumeIDs, h.VolumeID)
+	}
+	c.refreshVolumeMeta(ctx, volumeIDs)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, h := range VolumeQoSHistograms.Result.QosHistograms {
+		metadata, ok := c.reportedVolume(h.VolumeID)
+		if !ok {
 			continue
 		}
+		values := c.volumeLabelValues(metadata)
 		// Below Min IOPS Percentage
 		BelowMinIopsPercentages := map[float64]uint64{
 			19:  h.Histograms.BelowMinIopsPercentages.Bucket1To19,
@@ -738,7 +1271,7 @@ func (c *SolidfireCollector) collectVolumeQosHistograms(ctx context.Context, ch
 		}
 
 		ch <- prometheus.MustNewConstHistogram(
-			MetricDescriptions.VolumeQoSBelowMinIopsPercentagesHistogram,
+			c.metrics.VolumeQoSBelowMinIopsPercentagesHistogram,
 			0,
 			float64(sumHistogram(BelowMinIopsPercentages)),
 			BelowMinIopsPercentages,
@@ -754,7 +1287,7 @@ func (c *SolidfireCollector) collectVolumeQosHistograms(ctx context.Context, ch
 		}
 
 		ch <- prometheus.MustNewConstHistogram(
-			MetricDescriptions.VolumeQoSMinToMaxIopsPercentagesHistogram,
+			c.metrics.VolumeQoSMinToMaxIopsPercentagesHistogram,
 			0,
 			float64(sumHistogram(MinToMaxIopsPercentages)),
 			MinToMaxIopsPercentages,
@@ -770,7 +1303,7 @@ func (c *SolidfireCollector) collectVolumeQosHistograms(ctx context.Context, ch
 		}
 
 		ch <- prometheus.MustNewConstHistogram(
-			MetricDescriptions.VolumeQoSReadBlockSizesHistogram,
+			c.metrics.VolumeQoSReadBlockSizesHistogram,
 			0,
 			float64(sumHistogram(ReadBlockSizes)),
 			ReadBlockSizes,
@@ -787,7 +1320,7 @@ func (c *SolidfireCollector) collectVolumeQosHistograms(ctx context.Context, ch
 		}
 
 		ch <- prometheus.MustNewConstHistogram(
-			MetricDescriptions.VolumeQoSTargetUtilizationPercentagesHistogram,
+			c.metrics.VolumeQoSTargetUtilizationPercentagesHistogram,
 			0,
 			float64(sumHistogram(TargetUtilizationPercentages)),
 			TargetUtilizationPercentages,
@@ -804,7 +1337,7 @@ func (c *SolidfireCollector) collectVolumeQosHistograms(ctx context.Context, ch
 		}
 
 		ch <- prometheus.MustNewConstHistogram(
-			MetricDescriptions.VolumeQoSThrottlePercentagesHistogram,
+			c.metrics.VolumeQoSThrottlePercentagesHistogram,
 			0,
 			float64(sumHistogram(ThrottlePercentages)),
 			ThrottlePercentages,
@@ -821,7 +1354,7 @@ func (c *SolidfireCollector) collectVolumeQosHistograms(ctx context.Context, ch
 		}
 
 		ch <- prometheus.MustNewConstHistogram(
-			MetricDescriptions.VolumeQoSWriteBlockSizesHistogram,
+			c.metrics.VolumeQoSWriteBlockSizesHistogram,
 			0,
 			float64(sumHistogram(WriteBlockSizes)),
 			WriteBlockSizes,
@@ -838,139 +1371,139 @@ func (c *SolidfireCollector) collectClusterStats(ctx context.Context, ch chan<-
 	}
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterActualIOPS,
+		c.metrics.ClusterActualIOPS,
 		prometheus.GaugeValue,
 		clusterStats.Result.ClusterStats.ActualIOPS,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterAverageIOBytes,
+		c.metrics.ClusterAverageIOBytes,
 		prometheus.GaugeValue,
 		clusterStats.Result.ClusterStats.AverageIOPSize,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterClientQueueDepth,
+		c.metrics.ClusterClientQueueDepth,
 		prometheus.GaugeValue,
 		clusterStats.Result.ClusterStats.ClientQueueDepth,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterThroughputUtilization,
+		c.metrics.ClusterThroughputUtilization,
 		prometheus.GaugeValue,
 		clusterStats.Result.ClusterStats.ClusterUtilization,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterLatencySeconds,
+		c.metrics.ClusterLatencySeconds,
 		prometheus.GaugeValue,
 		MicrosecondsToSeconds(clusterStats.Result.ClusterStats.LatencyUSec),
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterNormalizedIOPS,
+		c.metrics.ClusterNormalizedIOPS,
 		prometheus.GaugeValue,
 		clusterStats.Result.ClusterStats.NormalizedIOPS,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterReadBytesTotal,
+		c.metrics.ClusterReadBytesTotal,
 		prometheus.CounterValue,
 		clusterStats.Result.ClusterStats.ReadBytes,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterLastSampleReadBytes,
+		c.metrics.ClusterLastSampleReadBytes,
 		prometheus.GaugeValue,
 		clusterStats.Result.ClusterStats.ReadBytesLastSample,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterReadLatencySeconds,
+		c.metrics.ClusterReadLatencySeconds,
 		prometheus.GaugeValue,
 		MicrosecondsToSeconds(clusterStats.Result.ClusterStats.ReadLatencyUSec),
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterReadLatencyTotal,
+		c.metrics.ClusterReadLatencyTotal,
 		prometheus.CounterValue,
 		MicrosecondsToSeconds(clusterStats.Result.ClusterStats.ReadLatencyUSecTotal),
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterReadOpsTotal,
+		c.metrics.ClusterReadOpsTotal,
 		prometheus.CounterValue,
 		clusterStats.Result.ClusterStats.ReadOps,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterLastSampleReadOps,
+		c.metrics.ClusterLastSampleReadOps,
 		prometheus.GaugeValue,
 		clusterStats.Result.ClusterStats.ReadOpsLastSample,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterSamplePeriodSeconds,
+		c.metrics.ClusterSamplePeriodSeconds,
 		prometheus.GaugeValue,
 		MillisecondsToSeconds(clusterStats.Result.ClusterStats.SamplePeriodMsec),
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterServices,
+		c.metrics.ClusterServices,
 		prometheus.GaugeValue,
 		clusterStats.Result.ClusterStats.ServicesCount,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterExpectedServices,
+		c.metrics.ClusterExpectedServices,
 		prometheus.GaugeValue,
 		clusterStats.Result.ClusterStats.ServicesTotal,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterUnalignedReadsTotal,
+		c.metrics.ClusterUnalignedReadsTotal,
 		prometheus.CounterValue,
 		clusterStats.Result.ClusterStats.UnalignedReads,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterUnalignedWritesTotal,
+		c.metrics.ClusterUnalignedWritesTotal,
 		prometheus.CounterValue,
 		clusterStats.Result.ClusterStats.UnalignedWrites,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterWriteBytesTotal,
+		c.metrics.ClusterWriteBytesTotal,
 		prometheus.CounterValue,
 		clusterStats.Result.ClusterStats.WriteBytes,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterLastSampleWriteBytes,
+		c.metrics.ClusterLastSampleWriteBytes,
 		prometheus.GaugeValue,
 		clusterStats.Result.ClusterStats.WriteBytesLastSample,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterWriteLatency,
+		c.metrics.ClusterWriteLatency,
 		prometheus.GaugeValue,
 		MicrosecondsToSeconds(clusterStats.Result.ClusterStats.WriteLatencyUSec),
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterWriteLatencyTotal,
+		c.metrics.ClusterWriteLatencyTotal,
 		prometheus.CounterValue,
 		MicrosecondsToSeconds(clusterStats.Result.ClusterStats.WriteLatencyUSecTotal),
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterWriteOpsTotal,
+		c.metrics.ClusterWriteOpsTotal,
 		prometheus.CounterValue,
 		clusterStats.Result.ClusterStats.WriteOps,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterLastSampleWriteOps,
+		c.metrics.ClusterLastSampleWriteOps,
 		prometheus.GaugeValue,
 		clusterStats.Result.ClusterStats.WriteOpsLastSample,
 	)
@@ -984,169 +1517,169 @@ func (c *SolidfireCollector) collectClusterFullThreshold(ctx context.Context, ch
 	}
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterBlockFullness,
+		c.metrics.ClusterBlockFullness,
 		prometheus.GaugeValue,
 		float64(strCompare(clusterFullThreshold.Result.BlockFullness, "stage1Happy")),
 		"stage1Happy",
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterBlockFullness,
+		c.metrics.ClusterBlockFullness,
 		prometheus.GaugeValue,
 		float64(strCompare(clusterFullThreshold.Result.BlockFullness, "stage2Aware")),
 		"stage2Aware",
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterBlockFullness,
+		c.metrics.ClusterBlockFullness,
 		prometheus.GaugeValue,
 		float64(strCompare(clusterFullThreshold.Result.BlockFullness, "stage3Low")),
 		"stage3Low",
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterBlockFullness,
+		c.metrics.ClusterBlockFullness,
 		prometheus.GaugeValue,
 		float64(strCompare(clusterFullThreshold.Result.BlockFullness, "stage4Critical")),
 		"stage4Critical",
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterBlockFullness,
+		c.metrics.ClusterBlockFullness,
 		prometheus.GaugeValue,
 		float64(strCompare(clusterFullThreshold.Result.BlockFullness, "stage5CompletelyConsumed")),
 		"stage5CompletelyConsumed",
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterFullness,
+		c.metrics.ClusterFullness,
 		prometheus.GaugeValue,
 		float64(strCompare(clusterFullThreshold.Result.Fullness, "blockFullness")),
 		"blockFullness",
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterFullness,
+		c.metrics.ClusterFullness,
 		prometheus.GaugeValue,
 		float64(strCompare(clusterFullThreshold.Result.Fullness, "metadataFullness")),
 		"metadataFullness",
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterMaxMetadataOverProvisionFactor,
+		c.metrics.ClusterMaxMetadataOverProvisionFactor,
 		prometheus.GaugeValue,
 		clusterFullThreshold.Result.MaxMetadataOverProvisionFactor,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterMetadataFullness,
+		c.metrics.ClusterMetadataFullness,
 		prometheus.GaugeValue,
 		float64(strCompare(clusterFullThreshold.Result.MetadataFullness, "stage1Happy")),
 		"stage1Happy",
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterMetadataFullness,
+		c.metrics.ClusterMetadataFullness,
 		prometheus.GaugeValue,
 		float64(strCompare(clusterFullThreshold.Result.MetadataFullness, "stage2Aware")),
 		"stage2Aware",
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterMetadataFullness,
+		c.metrics.ClusterMetadataFullness,
 		prometheus.GaugeValue,
 		float64(strCompare(clusterFullThreshold.Result.MetadataFullness, "stage3Low")),
 		"stage3Low",
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterMetadataFullness,
+		c.metrics.ClusterMetadataFullness,
 		prometheus.GaugeValue,
 		float64(strCompare(clusterFullThreshold.Result.MetadataFullness, "stage4Critical")),
 		"stage4Critical",
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterMetadataFullness,
+		c.metrics.ClusterMetadataFullness,
 		prometheus.GaugeValue,
 		float64(strCompare(clusterFullThreshold.Result.MetadataFullness, "stage5CompletelyConsumed")),
 		"stage5CompletelyConsumed",
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterSliceReserveUsedThresholdPercentage,
+		c.metrics.ClusterSliceReserveUsedThresholdPercentage,
 		prometheus.GaugeValue,
 		clusterFullThreshold.Result.SliceReserveUsedThresholdPct,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterStage2AwareThresholdPercentage,
+		c.metrics.ClusterStage2AwareThresholdPercentage,
 		prometheus.GaugeValue,
 		clusterFullThreshold.Result.Stage2AwareThreshold,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterStage2BlockThresholdBytes,
+		c.metrics.ClusterStage2BlockThresholdBytes,
 		prometheus.GaugeValue,
 		clusterFullThreshold.Result.Stage2BlockThresholdBytes,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterStage3BlockThresholdBytes,
+		c.metrics.ClusterStage3BlockThresholdBytes,
 		prometheus.GaugeValue,
 		clusterFullThreshold.Result.Stage3BlockThresholdBytes,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterStage3BlockThresholdPercentage,
+		c.metrics.ClusterStage3BlockThresholdPercentage,
 		prometheus.GaugeValue,
 		clusterFullThreshold.Result.Stage3BlockThresholdPercent,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterStage3LowThresholdPercentage,
+		c.metrics.ClusterStage3LowThresholdPercentage,
 		prometheus.GaugeValue,
 		clusterFullThreshold.Result.Stage3LowThreshold,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterStage4BlockThresholdBytes,
+		c.metrics.ClusterStage4BlockThresholdBytes,
 		prometheus.GaugeValue,
 		clusterFullThreshold.Result.Stage4BlockThresholdBytes,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterStage4CriticalThreshold,
+		c.metrics.ClusterStage4CriticalThreshold,
 		prometheus.GaugeValue,
 		clusterFullThreshold.Result.Stage4CriticalThreshold,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterStage5BlockThresholdBytes,
+		c.metrics.ClusterStage5BlockThresholdBytes,
 		prometheus.GaugeValue,
 		clusterFullThreshold.Result.Stage5BlockThresholdBytes,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterTotalBytes,
+		c.metrics.ClusterTotalBytes,
 		prometheus.GaugeValue,
 		clusterFullThreshold.Result.SumTotalClusterBytes,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterTotalMetadataBytes,
+		c.metrics.ClusterTotalMetadataBytes,
 		prometheus.GaugeValue,
 		clusterFullThreshold.Result.SumTotalMetadataClusterBytes,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterUsedBytes,
+		c.metrics.ClusterUsedBytes,
 		prometheus.GaugeValue,
 		clusterFullThreshold.Result.SumUsedClusterBytes,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.ClusterUsedMetadataBytes,
+		c.metrics.ClusterUsedMetadataBytes,
 		prometheus.GaugeValue,
 		clusterFullThreshold.Result.SumUsedMetadataClusterBytes,
 	)
@@ -1158,8 +1691,8 @@ func (c *SolidfireCollector) collectDriveDetails(ctx context.Context, ch chan<-
 	if err != nil {
 		return err
 	}
-	mu.Lock()
-	defer mu.Unlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	for _, d := range ListDrives.Result.Drives {
 		for _, ds := range possibleDriveStatuses {
 			var driveStatusValue float64 = 0
@@ -1167,11 +1700,11 @@ func (c *SolidfireCollector) collectDriveDetails(ctx context.Context, ch chan<-
 				driveStatusValue = 1
 			}
 			ch <- prometheus.MustNewConstMetric(
-				MetricDescriptions.DriveStatus,
+				c.metrics.DriveStatus,
 				prometheus.GaugeValue,
 				driveStatusValue,
 				strconv.Itoa(d.NodeID),
-				c.nodesNamesByID[d.NodeID],
+				c.nodeName(d.NodeID),
 				strconv.Itoa(d.DriveID),
 				d.Serial,
 				strconv.Itoa(d.Slot),
@@ -1181,11 +1714,11 @@ func (c *SolidfireCollector) collectDriveDetails(ctx context.Context, ch chan<-
 		}
 
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.DriveCapacityBytes,
+			c.metrics.DriveCapacityBytes,
 			prometheus.GaugeValue,
 			d.Capacity,
 			strconv.Itoa(d.NodeID),
-			c.nodesNamesByID[d.NodeID],
+			c.nodeName(d.NodeID),
 			strconv.Itoa(d.DriveID),
 			d.Serial,
 			strconv.Itoa(d.Slot),
@@ -1195,13 +1728,21 @@ func (c *SolidfireCollector) collectDriveDetails(ctx context.Context, ch chan<-
 	return nil
 }
 
+// listISCSISessions lists the iSCSI sessions once per scrape for the iscsi and
+// volume_state collectors.
+func (c *SolidfireCollector) listISCSISessions(ctx context.Context) (solidfire.ListISCSISessionsResponse, error) {
+	return shared(ctx, string(solidfire.RPCListISCSISessions), func() (solidfire.ListISCSISessionsResponse, error) {
+		return c.client.ListISCSISessions(ctx)
+	})
+}
+
 func (c *SolidfireCollector) collectISCSISessions(ctx context.Context, ch chan<- prometheus.Metric) error {
-	ListISCSISessions, err := c.client.ListISCSISessions(ctx)
+	ListISCSISessions, err := c.listISCSISessions(ctx)
 	if err != nil {
 		return err
 	}
-	mu.Lock()
-	defer mu.Unlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	sessions := make(map[int]float64)
 
 	for _, session := range ListISCSISessions.Result.Sessions {
@@ -1210,25 +1751,25 @@ func (c *SolidfireCollector) collectISCSISessions(ctx context.Context, ch chan<-
 
 	for node, val := range sessions {
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.NodeISCSISessions,
+			c.metrics.NodeISCSISessions,
 			prometheus.GaugeValue,
 			val,
 			strconv.Itoa(node),
-			c.nodesNamesByID[node],
+			c.nodeName(node),
 		)
 	}
 	return nil
 }
 
 func (c *SolidfireCollector) collectAccounts(ctx context.Context, ch chan<- prometheus.Metric) error {
-	accounts, err := c.client.ListAccounts(ctx)
+	accounts, err := c.listAccounts(ctx)
 	if err != nil {
 		return err
 	}
-	mu.Lock()
-	defer mu.Unlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.AccountCount,
+		c.metrics.AccountCount,
 		prometheus.CounterValue,
 		float64(len(accounts.Result.Accounts)),
 	)
@@ -1240,10 +1781,10 @@ func (c *SolidfireCollector) collectInitiators(ctx context.Context, ch chan<- pr
 	if err != nil {
 		return err
 	}
-	mu.Lock()
-	defer mu.Unlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.InitiatorCount,
+		c.metrics.InitiatorCount,
 		prometheus.CounterValue,
 		float64(len(initiators.Result.Initiators)),
 	)
@@ -1255,10 +1796,10 @@ func (c *SolidfireCollector) collectVolumeAccessGroups(ctx context.Context, ch c
 	if err != nil {
 		return err
 	}
-	mu.Lock()
-	defer mu.Unlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.VolumeAccessGroupCount,
+		c.metrics.VolumeAccessGroupCount,
 		prometheus.CounterValue,
 		float64(len(volumeAccessGroups.Result.VolumeAccessGroups)),
 	)
@@ -1270,10 +1811,10 @@ func (c *SolidfireCollector) collectVirtualVolumeTasks(ctx context.Context, ch c
 	if err != nil {
 		return err
 	}
-	mu.Lock()
-	defer mu.Unlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.VirtualVolumeTasks,
+		c.metrics.VirtualVolumeTasks,
 		prometheus.CounterValue,
 		float64(len(vvt.Result.Tasks)),
 	)
@@ -1285,10 +1826,10 @@ func (c *SolidfireCollector) collectBulkVolumeJobs(ctx context.Context, ch chan<
 	if err != nil {
 		return err
 	}
-	mu.Lock()
-	defer mu.Unlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.BulkVolumeJobs,
+		c.metrics.BulkVolumeJobs,
 		prometheus.CounterValue,
 		float64(len(btj.Result.BulkVolumeJobs)),
 	)
@@ -1323,11 +1864,11 @@ func (c *SolidfireCollector) collectAsyncResults(ctx context.Context, ch chan<-
 		}
 	}
 
-	mu.Lock()
-	defer mu.Unlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	for k, v := range activeAsyncResults {
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.AsyncResultsActive,
+			c.metrics.AsyncResultsActive,
 			prometheus.GaugeValue,
 			float64(v),
 			k,
@@ -1335,89 +1876,116 @@ func (c *SolidfireCollector) collectAsyncResults(ctx context.Context, ch chan<-
 	}
 	for k, v := range allAsyncResults {
 		ch <- prometheus.MustNewConstMetric(
-			MetricDescriptions.AsyncResults,
+			c.metrics.AsyncResults,
 			prometheus.GaugeValue,
 			float64(v),
 			k,
 		)
 	}
 	ch <- prometheus.MustNewConstMetric(
-		MetricDescriptions.MaxAsyncResultID,
+		c.metrics.MaxAsyncResultID,
 		prometheus.GaugeValue,
 		float64(maxAsyncResultID),
 	)
 	return nil
 }
 
+// Collect runs the metadata collectors and then every enabled collector. Each
+// collector fails independently: metrics of the others are still reported,
+// collectors that depend on metadata fall back to the last known one, and
+// solidfire_up is 1 as long as the API answered any call.
 func (c *SolidfireCollector) Collect(ch chan<- prometheus.Metric) {
 	var up float64 = 0
-	defer func() { ch <- prometheus.MustNewConstMetric(MetricDescriptions.upDesc, prometheus.GaugeValue, up) }()
+	defer func() { ch <- prometheus.MustNewConstMetric(c.metrics.upDesc, prometheus.GaugeValue, up) }()
+	defer c.collectAPIVersion(ch)
 	timeout := c.timeout
-	parentCtx, _ := context.WithTimeout(context.Background(), timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	ctx = withScrapeResults(ctx)
+
+	succeeded := c.runCollectors(ctx, ch, metadataSubsystems)
+	succeeded += c.runCollectors(ctx, ch, c.subsystems)
+	if succeeded > 0 {
+		up = 1
+	}
+}
 
-	metadataGroup, ctx := errgroup.WithContext(parentCtx)
-	metadataGroup.Go(func() error {
-		return c.collectVolumeMeta(ctx, ch)
-	})
-	metadataGroup.Go(func() error {
-		return c.collectNodeMeta(ctx, ch)
-	})
-	if err := metadataGroup.Wait(); err != nil {
-		log.Errorln(err)
-		return
+// runCollectors runs subsystems concurrently, logs their errors and returns
+// how many succeeded.
+func (c *SolidfireCollector) runCollectors(ctx context.Context, ch chan<- prometheus.Metric, subsystems []subsystem) int {
+	var wg sync.WaitGroup
+	var succeeded int32
+	for _, s := range subsystems {
+		s := s
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := c.runCollector(ctx, ch, s.name, s.collect); err != nil {
+				logCollectError(s.name, err)
+				return
+			}
+			atomic.AddInt32(&succeeded, 1)
+		}()
 	}
-	metricsGroup, ctx := errgroup.WithContext(parentCtx)
-	metricsGroup.Go(func() error {
-		return c.collectVolumeStats(ctx, ch)
-	})
-	metricsGroup.Go(func() error {
-		return c.collectClusterCapacity(ctx, ch)
-	})
-	metricsGroup.Go(func() error {
-		return c.collectClusterFaults(ctx, ch)
-	})
-	metricsGroup.Go(func() error {
-		return c.collectClusterNodeStats(ctx, ch)
-	})
-	metricsGroup.Go(func() error {
-		return c.collectVolumeQosHistograms(ctx, ch)
-	})
-	metricsGroup.Go(func() error {
-		return c.collectClusterStats(ctx, ch)
-	})
-	metricsGroup.Go(func() error {
-		return c.collectClusterFullThreshold(ctx, ch)
-	})
-	metricsGroup.Go(func() error {
-		return c.collectDriveDetails(ctx, ch)
-	})
-	metricsGroup.Go(func() error {
-		return c.collectISCSISessions(ctx, ch)
-	})
-	metricsGroup.Go(func() error {
-		return c.collectAccounts(ctx, ch)
-	})
-	metricsGroup.Go(func() error {
-		return c.collectInitiators(ctx, ch)
-	})
-	metricsGroup.Go(func() error {
-		return c.collectVolumeAccessGroups(ctx, ch)
-	})
-	metricsGroup.Go(func() error {
-		return c.collectVirtualVolumeTasks(ctx, ch)
-	})
-	metricsGroup.Go(func() error {
-		return c.collectBulkVolumeJobs(ctx, ch)
-	})
-	metricsGroup.Go(func() error {
-		return c.collectAsyncResults(ctx, ch)
-	})
-	if err := metricsGroup.Wait(); err != nil {
-		log.Errorln(err)
+	wg.Wait()
+	return int(succeeded)
+}
+
+// runCollector runs one collector and reports its duration and success.
+func (c *SolidfireCollector) runCollector(ctx context.Context, ch chan<- prometheus.Metric, name string, collect collectFunc) error {
+	start := time.Now()
+	err := collect(c, ctx, ch)
+	collectStatus(ch, name, time.Since(start), err == nil)
+	return err
+}
+
+// collectStatus reports the duration and success of one collector run.
+func collectStatus(ch chan<- prometheus.Metric, name string, duration time.Duration, ok bool) {
+	success := 0.0
+	if ok {
+		success = 1
+	}
+	ch <- prometheus.MustNewConstMetric(
+		MetricDescriptions.ScrapeCollectorDuration,
+		prometheus.GaugeValue,
+		duration.Seconds(),
+		name,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		MetricDescriptions.ScrapeCollectorSuccess,
+		prometheus.GaugeValue,
+		success,
+		name,
+	)
+}
+
+// collectAPIVersion reports the API version in use. It is deferred so the
+// version negotiated during the scrape is reported.
+func (c *SolidfireCollector) collectAPIVersion(ch chan<- prometheus.Metric) {
+	if v := c.client.APIVersion(); v != "" {
+		ch <- prometheus.MustNewConstMetric(
+			c.metrics.APIVersionInfo,
+			prometheus.GaugeValue,
+			1,
+			v,
+		)
+	}
+}
+
+// logCollectError logs the failure of a collector. Permission errors name the
+// method the API user may not call, since they need a change to the API user
+// rather than to the exporter or the network.
+func logCollectError(collector string, err error) {
+	var apiErr *solidfire.APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsPermissionDenied() {
+		log.Errorf("%s collector failed: %v", collector, err)
 		return
 	}
-	up = 1
-	return
+	hint := ""
+	if apiErr.Method == solidfire.RPCListVolumeQoSHistograms {
+		hint = " (Element 11.3 requires Administrator access for QoS histograms)"
+	}
+	log.Errorf("%s collector failed: the API user lacks the access level required by %s%s: %v", collector, apiErr.Method, hint, err)
 }
 
 func NewCollector(opts *CollectorOpts) (*SolidfireCollector, error) {
@@ -1431,11 +1999,33 @@ func NewCollector(opts *CollectorOpts) (*SolidfireCollector, error) {
 			return nil, err
 		}
 	}
+	subsystems, err := enabledSubsystems(opts.Collectors)
+	if err != nil {
+		return nil, err
+	}
+	volumeFilter, err := newVolumeFilter(opts.VolumeFilter)
+	if err != nil {
+		return nil, err
+	}
+	labelNames, err := opts.VolumeLabels.labelNames()
+	if err != nil {
+		return nil, err
+	}
+	metrics := MetricDescriptions
+	if len(labelNames) > 0 {
+		metrics = NewMetricDescriptions("solidfire", labelNames...)
+	}
 	return &SolidfireCollector{
-		volumeMetadataByID: make(map[int]volumeMetadata),
-		nodesNamesByID:     make(map[int]string),
-		client:             opts.Client,
-		timeout:            opts.Timeout,
+		volumes:      newInventory[volumeMetadata]("volume"),
+		nodes:        newInventory[string]("node"),
+		client:       opts.Client,
+		timeout:      opts.Timeout,
+		subsystems:   subsystems,
+		volumeFilter: volumeFilter,
+		volumeLabels: opts.VolumeLabels,
+		metrics:      metrics,
+		idleAfter:    idleAfterOrDefault(opts.IdleAfter),
+		ensemble:     &ensembleState{},
 	}, nil
 }
 
@@ -140,8 +140,13 @@ func Test_Collect(t *testing.T) {
 			r := prometheus.NewRegistry()
 			r.MustRegister(collector)
 			got := testutils.PrometheusOutput(t, r, "solidfire")
-			var sortedGot = make([]string, len(got))
-			copy(sortedGot, got)
+			var sortedGot []string
+			for _, line := range got {
+				// durations differ between runs
+				if !strings.HasPrefix(line, "solidfire_scrape_collector_duration_seconds") {
+					sortedGot = append(sortedGot, line)
+				}
+			}
 			sort.Strings(sortedGot)
 			sort.Strings(tt.want)
 			assert.Equal(t, tt.want, sortedGot, fmt.Sprintf("Here is the full output I got from the collector:\n%s\n", strings.Join(got, "\n")))
@@ -149,6 +154,77 @@ func Test_Collect(t *testing.T) {
 	}
 }
 
+func Test_Collect_Unreachable(t *testing.T) {
+	unreachable := errors.New("connection refused")
+	mockErrs := mockErrors{}
+	for _, rpc := range []solidfire.RPC{
+		solidfire.RPCGetClusterCapacity, solidfire.RPCGetClusterFullThreshold, solidfire.RPCGetClusterStats,
+		solidfire.RPCListAllNodes, solidfire.RPCListClusterFaults, solidfire.RPCListDrives,
+		solidfire.RPCListISCSISessions, solidfire.RPCListNodeStats, solidfire.RPCListVolumeQoSHistograms,
+		solidfire.RPCListVolumes, solidfire.RPCListVolumeStats, solidfire.RPCListAccounts,
+		solidfire.RPCListInitiators, solidfire.RPCListVolumeAccessGroups, solidfire.RPCListVirtualVolumeTasks,
+		solidfire.RPCListBulkVolumeJobs, solidfire.RPCListAsyncResults,
+		solidfire.RPCGetIpmiInfo, solidfire.RPCGetNodeHardwareInfo,
+		solidfire.RPCGetClusterMasterNodeID,
+		solidfire.RPCGetClusterInfo, solidfire.RPCGetClusterVersionInfo,
+		solidfire.RPCListSnapMirrorRelationships,
+		solidfire.RPCListSnapMirrorEndpoints,
+		solidfire.RPCListActivePairedVolumes,
+		solidfire.RPCListClusterPairs,
+		solidfire.RPCListSchedules,
+		solidfire.RPCListGroupSnapshots,
+		solidfire.RPCListSnapshots,
+		solidfire.RPCListDeletedVolumes,
+		solidfire.RPCListQoSPolicies,
+	} {
+		mockErrs[rpc] = unreachable
+	}
+	collector, err := prom.NewCollector(&prom.CollectorOpts{Client: newMockedClient(t, mockErrs), Timeout: time.Second})
+	require.NoError(t, err)
+	r := prometheus.NewRegistry()
+	r.MustRegister(collector)
+	got := testutils.PrometheusOutput(t, r, "solidfire")
+
+	assert.Contains(t, got, "solidfire_up 0")
+	assert.Contains(t, got, `solidfire_scrape_collector_success{collector="volume_meta"} 0`)
+	assert.Contains(t, got, `solidfire_scrape_collector_success{collector="cluster_capacity"} 0`)
+}
+
+func Test_Collect_VolumeQoSWithoutPolicies(t *testing.T) {
+	client := newMockedClient(t, mockErrors{solidfire.RPCListQoSPolicies: errors.New("xUnknownAPIMethod")})
+	collector, err := prom.NewCollector(&prom.CollectorOpts{
+		Client:     client,
+		Timeout:    time.Second,
+		Collectors: map[string]bool{"volume_qos": true},
+	})
+	require.NoError(t, err)
+	r := prometheus.NewRegistry()
+	r.MustRegister(collector)
+	got := testutils.PrometheusOutput(t, r, "solidfire")
+
+	assert.Contains(t, got, `solidfire_scrape_collector_success{collector="volume_qos"} 0`)
+	assert.Contains(t, got, `solidfire_volume_qos_min_iops{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 50`)
+	for _, line := range got {
+		assert.False(t, strings.HasPrefix(line, "solidfire_volume_qos_policy_info"), line)
+	}
+}
+
+func Test_Collect_RefreshesVolumeMetaOnce(t *testing.T) {
+	client := newMockedClient(t, mockErrors{solidfire.RPCListVolumes: errors.New("connection refused")})
+	collector, err := prom.NewCollector(&prom.CollectorOpts{
+		Client:     client,
+		Timeout:    time.Second,
+		Collectors: map[string]bool{"volume_stats": true, "qos_histograms": true},
+	})
+	require.NoError(t, err)
+	r := prometheus.NewRegistry()
+	r.MustRegister(collector)
+	testutils.PrometheusOutput(t, r, "solidfire")
+
+	// volume_meta, then one forced refresh shared by both collectors
+	client.AssertNumberOfCalls(t, string(solidfire.RPCListVolumes), 2)
+}
+
 type mockErrors map[solidfire.RPC]error
 
 func newMockedClient(t *testing.T, mockErrs mockErrors) *testutils.MockSolidfireClient {
@@ -273,5 +349,105 @@ func newMockedClient(t *testing.T, mockErrs mockErrors) *testutils.MockSolidfire
 	require.NoError(t, json.Unmarshal(bytes, &listVirtualVolumeTasksResponse))
 	mockSfClient.On(string(call), mock.Anything).Return(listVirtualVolumeTasksResponse, mockErrs[call])
 
+	getClusterInfoResponse := solidfire.GetClusterInfoResponse{}
+	call = solidfire.RPCGetClusterInfo
+	bytes, err = ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, call))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(bytes, &getClusterInfoResponse))
+	mockSfClient.On(string(call), mock.Anything).Return(getClusterInfoResponse, mockErrs[call])
+
+	listQoSPoliciesResponse := solidfire.ListQoSPoliciesResponse{}
+	call = solidfire.RPCListQoSPolicies
+	bytes, err = ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, call))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(bytes, &listQoSPoliciesResponse))
+	mockSfClient.On(string(call), mock.Anything).Return(listQoSPoliciesResponse, mockErrs[call])
+
+	listDeletedVolumesResponse := solidfire.ListDeletedVolumesResponse{}
+	call = solidfire.RPCListDeletedVolumes
+	bytes, err = ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, call))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(bytes, &listDeletedVolumesResponse))
+	mockSfClient.On(string(call), mock.Anything).Return(listDeletedVolumesResponse, mockErrs[call])
+
+	listSnapshotsResponse := solidfire.ListSnapshotsResponse{}
+	call = solidfire.RPCListSnapshots
+	bytes, err = ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, call))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(bytes, &listSnapshotsResponse))
+	mockSfClient.On(string(call), mock.Anything).Return(listSnapshotsResponse, mockErrs[call])
+
+	listGroupSnapshotsResponse := solidfire.ListGroupSnapshotsResponse{}
+	call = solidfire.RPCListGroupSnapshots
+	bytes, err = ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, call))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(bytes, &listGroupSnapshotsResponse))
+	mockSfClient.On(string(call), mock.Anything).Return(listGroupSnapshotsResponse, mockErrs[call])
+
+	listSchedulesResponse := solidfire.ListSchedulesResponse{}
+	call = solidfire.RPCListSchedules
+	bytes, err = ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, call))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(bytes, &listSchedulesResponse))
+	mockSfClient.On(string(call), mock.Anything).Return(listSchedulesResponse, mockErrs[call])
+
+	listClusterPairsResponse := solidfire.ListClusterPairsResponse{}
+	call = solidfire.RPCListClusterPairs
+	bytes, err = ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, call))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(bytes, &listClusterPairsResponse))
+	mockSfClient.On(string(call), mock.Anything).Return(listClusterPairsResponse, mockErrs[call])
+
+	listActivePairedVolumesResponse := solidfire.ListActivePairedVolumesResponse{}
+	call = solidfire.RPCListActivePairedVolumes
+	bytes, err = ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, call))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(bytes, &listActivePairedVolumesResponse))
+	mockSfClient.On(string(call), mock.Anything).Return(listActivePairedVolumesResponse, mockErrs[call])
+
+	listSnapMirrorEndpointsResponse := solidfire.ListSnapMirrorEndpointsResponse{}
+	call = solidfire.RPCListSnapMirrorEndpoints
+	bytes, err = ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, call))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(bytes, &listSnapMirrorEndpointsResponse))
+	mockSfClient.On(string(call), mock.Anything).Return(listSnapMirrorEndpointsResponse, mockErrs[call])
+
+	listSnapMirrorRelationshipsResponse := solidfire.ListSnapMirrorRelationshipsResponse{}
+	call = solidfire.RPCListSnapMirrorRelationships
+	bytes, err = ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, call))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(bytes, &listSnapMirrorRelationshipsResponse))
+	mockSfClient.On(string(call), mock.Anything).Return(listSnapMirrorRelationshipsResponse, mockErrs[call])
+
+	getClusterVersionInfoResponse := solidfire.GetClusterVersionInfoResponse{}
+	call = solidfire.RPCGetClusterVersionInfo
+	bytes, err = ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, call))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(bytes, &getClusterVersionInfoResponse))
+	mockSfClient.On(string(call), mock.Anything).Return(getClusterVersionInfoResponse, mockErrs[call])
+
+	getClusterMasterNodeIDResponse := solidfire.GetClusterMasterNodeIDResponse{}
+	call = solidfire.RPCGetClusterMasterNodeID
+	bytes, err = ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, call))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(bytes, &getClusterMasterNodeIDResponse))
+	mockSfClient.On(string(call), mock.Anything).Return(getClusterMasterNodeIDResponse, mockErrs[call])
+
+	getIpmiInfoResponse := solidfire.GetIpmiInfoResponse{}
+	call = solidfire.RPCGetIpmiInfo
+	bytes, err = ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, call))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(bytes, &getIpmiInfoResponse))
+	mockSfClient.On(string(call), mock.Anything).Return(getIpmiInfoResponse, mockErrs[call])
+
+	getNodeHardwareInfoResponse := solidfire.GetNodeHardwareInfoResponse{}
+	call = solidfire.RPCGetNodeHardwareInfo
+	bytes, err = ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, call))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(bytes, &getNodeHardwareInfoResponse))
+	mockSfClient.On(string(call), mock.Anything, mock.Anything).Return(getNodeHardwareInfoResponse, mockErrs[call])
+
+	mockSfClient.On("APIVersion").Return("11.3")
+
 	return mockSfClient
 }
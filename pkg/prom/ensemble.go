@@ -0,0 +1,100 @@
+package prom
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	log "github.com/amoghe/distillog"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ensembleState is the cluster master seen by the last ensemble run, and how
+// often it changed. It must live as long as the cluster is scraped: on the
+// collector for /metrics, and on the cached client of the target for /probe,
+// whose collectors only last one probe.
+type ensembleState struct {
+	mu            sync.Mutex
+	masterNodeID  int
+	masterChanges uint64
+}
+
+// observe records the current master and returns the previous one, or 0 on
+// the first run, and the number of changes so far.
+func (s *ensembleState) observe(masterID int) (previous int, changes uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous = s.masterNodeID
+	if previous != 0 && masterID != previous {
+		s.masterChanges++
+	}
+	s.masterNodeID = masterID
+	return previous, s.masterChanges
+}
+
+// collectEnsemble reports which node holds the cluster master role and which
+// nodes are ensemble members, and counts the master changes seen by this
+// collector. GetClusterInfo lists the ensemble by cluster IP, which is matched
+// against the nodes of ListAllNodes. Both listings are shared with the
+// cluster_info and node_meta collectors within a scrape.
+func (c *SolidfireCollector) collectEnsemble(ctx context.Context, ch chan<- prometheus.Metric) error {
+	master, err := c.client.GetClusterMasterNodeID(ctx)
+	if err != nil {
+		return err
+	}
+	info, err := c.getClusterInfo(ctx)
+	if err != nil {
+		return err
+	}
+	nodes, err := c.listAllNodes(ctx)
+	if err != nil {
+		return err
+	}
+	ensemble := map[string]bool{}
+	for _, ip := range info.Result.ClusterInfo.Ensemble {
+		ensemble[ip] = true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	masterID := master.Result.NodeID
+	previous, changes := c.ensemble.observe(masterID)
+	if previous != 0 && masterID != previous {
+		log.Infof("cluster master moved from node %d (%s) to node %d (%s)", previous, c.nodeName(previous), masterID, c.nodeName(masterID))
+	}
+
+	for _, node := range nodes.Result.Nodes {
+		values := []string{strconv.Itoa(node.NodeID), c.nodeName(node.NodeID)}
+		var isMaster float64 = 0
+		if node.NodeID == masterID {
+			isMaster = 1
+		}
+		ch <- prometheus.MustNewConstMetric(
+			c.metrics.NodeClusterMaster,
+			prometheus.GaugeValue,
+			isMaster,
+			values...)
+
+		var isMember float64 = 0
+		if ensemble[node.Cip] {
+			isMember = 1
+		}
+		ch <- prometheus.MustNewConstMetric(
+			c.metrics.NodeEnsembleMember,
+			prometheus.GaugeValue,
+			isMember,
+			values...)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.metrics.ClusterEnsembleSize,
+		prometheus.GaugeValue,
+		float64(len(ensemble)),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.metrics.ClusterMasterChanges,
+		prometheus.CounterValue,
+		float64(changes),
+	)
+	return nil
+}
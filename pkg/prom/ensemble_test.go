@@ -0,0 +1,97 @@
+package prom_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mjavier2k/solidfire-exporter/pkg/prom"
+	"github.com/mjavier2k/solidfire-exporter/pkg/solidfire"
+	"github.com/mjavier2k/solidfire-exporter/pkg/testutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Collect_MasterChanges(t *testing.T) {
+	client := newMockedClient(t, mockErrors{})
+	var calls []*mock.Call
+	for _, call := range client.ExpectedCalls {
+		if call.Method != string(solidfire.RPCGetClusterMasterNodeID) {
+			calls = append(calls, call)
+		}
+	}
+	client.ExpectedCalls = calls
+	for _, nodeID := range []int{1, 2} {
+		master := solidfire.GetClusterMasterNodeIDResponse{}
+		master.Result.NodeID = nodeID
+		client.On(string(solidfire.RPCGetClusterMasterNodeID), mock.Anything).Return(master, nil).Once()
+	}
+
+	collector, err := prom.NewCollector(&prom.CollectorOpts{
+		Client:     client,
+		Timeout:    time.Second,
+		Collectors: map[string]bool{"ensemble": true},
+	})
+	require.NoError(t, err)
+	r := prometheus.NewRegistry()
+	r.MustRegister(collector)
+
+	got := testutils.PrometheusOutput(t, r, "solidfire")
+	assert.Contains(t, got, `solidfire_node_cluster_master{node_id="1",node_name="n01"} 1`)
+	assert.Contains(t, got, `solidfire_cluster_master_changes_total 0`)
+
+	got = testutils.PrometheusOutput(t, r, "solidfire")
+	assert.Contains(t, got, `solidfire_node_cluster_master{node_id="1",node_name="n01"} 0`)
+	assert.Contains(t, got, `solidfire_cluster_master_changes_total 1`)
+}
+
+func Test_ProbeHandler_MasterChanges(t *testing.T) {
+	var masters = []int{1, 2}
+	server := newFixtureServerWith(t, func(method solidfire.RPC) (interface{}, bool) {
+		if method != solidfire.RPCGetClusterMasterNodeID {
+			return nil, false
+		}
+		nodeID := masters[0]
+		masters = masters[1:]
+		return map[string]int{"nodeID": nodeID}, true
+	})
+	defer server.Close()
+
+	handler := prom.NewProbeHandler(&prom.ProbeHandlerOpts{
+		Modules: map[string]solidfire.Module{
+			solidfire.DefaultModule: {Username: "user", Password: "pass", Timeout: 5, APIVersion: "11.3"},
+		},
+		Timeout:   5 * time.Second,
+		CacheTTLs: map[solidfire.RPC]time.Duration{solidfire.RPCGetClusterMasterNodeID: time.Minute},
+	})
+	probe := func() string {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/probe?collect[]=ensemble&target="+server.URL, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		return rec.Body.String()
+	}
+
+	assert.Contains(t, probe(), "solidfire_cluster_master_changes_total 0")
+	assert.Contains(t, probe(), "solidfire_cluster_master_changes_total 1", "master state is kept per target and not cached")
+}
+
+func Test_Collect_EnsembleSharesNodesAndClusterInfo(t *testing.T) {
+	client := newMockedClient(t, mockErrors{})
+	collector, err := prom.NewCollector(&prom.CollectorOpts{
+		Client:     client,
+		Timeout:    time.Second,
+		Collectors: map[string]bool{"ensemble": true, "cluster_info": true},
+	})
+	require.NoError(t, err)
+	r := prometheus.NewRegistry()
+	r.MustRegister(collector)
+	got := testutils.PrometheusOutput(t, r, "solidfire")
+
+	assert.Contains(t, got, `solidfire_scrape_collector_success{collector="ensemble"} 1`)
+	assert.Contains(t, got, `solidfire_scrape_collector_success{collector="cluster_info"} 1`)
+	client.AssertNumberOfCalls(t, string(solidfire.RPCListAllNodes), 1)
+	client.AssertNumberOfCalls(t, string(solidfire.RPCGetClusterInfo), 1)
+}
@@ -0,0 +1,190 @@
+package prom
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// VolumeRule matches a volume when every field that is set matches. Name and
+// attribute values are regular expressions; attribute keys are matched
+// case-insensitively since config keys are lowercased.
+type VolumeRule struct {
+	Name                   string            `mapstructure:"name"`
+	AccountIDs             []int             `mapstructure:"account_ids"`
+	AccountNames           []string          `mapstructure:"account_names"`
+	VolumeAccessGroupIDs   []int             `mapstructure:"volume_access_group_ids"`
+	VolumeAccessGroupNames []string          `mapstructure:"volume_access_group_names"`
+	Statuses               []string          `mapstructure:"statuses"`
+	Attributes             map[string]string `mapstructure:"attributes"`
+}
+
+// VolumeFilterConfig selects the volumes reported by the per-volume metrics. A
+// volume is reported when it matches any Include rule, or Include is empty,
+// and matches no Exclude rule.
+type VolumeFilterConfig struct {
+	Include []VolumeRule `mapstructure:"include"`
+	Exclude []VolumeRule `mapstructure:"exclude"`
+}
+
+// DefaultVolumeExclude skips the source volumes of snapshot clones and
+// replication targets.
+var DefaultVolumeExclude = []VolumeRule{{Name: `snapshot-clone-src-*|replica-vol-*`}}
+
+type volumeFilter struct {
+	include []volumeRule
+	exclude []volumeRule
+}
+
+type volumeRule struct {
+	VolumeRule
+	name       *regexp.Regexp
+	attributes map[string]*regexp.Regexp
+}
+
+func newVolumeFilter(config VolumeFilterConfig) (*volumeFilter, error) {
+	include, err := compileVolumeRules(config.Include)
+	if err != nil {
+		return nil, fmt.Errorf("invalid volume include rule: %v", err)
+	}
+	exclude, err := compileVolumeRules(config.Exclude)
+	if err != nil {
+		return nil, fmt.Errorf("invalid volume exclude rule: %v", err)
+	}
+	return &volumeFilter{include: include, exclude: exclude}, nil
+}
+
+func compileVolumeRules(rules []VolumeRule) ([]volumeRule, error) {
+	compiled := make([]volumeRule, 0, len(rules))
+	for _, rule := range rules {
+		r := volumeRule{VolumeRule: rule, attributes: map[string]*regexp.Regexp{}}
+		if rule.Name != "" {
+			re, err := regexp.Compile(rule.Name)
+			if err != nil {
+				return nil, err
+			}
+			r.name = re
+		}
+		for key, value := range rule.Attributes {
+			re, err := regexp.Compile(value)
+			if err != nil {
+				return nil, fmt.Errorf("attribute %s: %v", key, err)
+			}
+			r.attributes[strings.ToLower(key)] = re
+		}
+		compiled = append(compiled, r)
+	}
+	return compiled, nil
+}
+
+// match reports whether the volume is reported.
+func (f *volumeFilter) match(v volumeMetadata) bool {
+	if len(f.include) > 0 && !anyRuleMatches(f.include, v) {
+		return false
+	}
+	return !anyRuleMatches(f.exclude, v)
+}
+
+// needsAccountNames reports whether any rule matches on account names, which
+// have to be looked up with ListAccounts.
+func (f *volumeFilter) needsAccountNames() bool {
+	for _, r := range append(append([]volumeRule{}, f.include...), f.exclude...) {
+		if len(r.AccountNames) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// needsVolumeAccessGroupNames reports whether any rule matches on volume
+// access group names, which have to be looked up with ListVolumeAccessGroups.
+func (f *volumeFilter) needsVolumeAccessGroupNames() bool {
+	for _, r := range append(append([]volumeRule{}, f.include...), f.exclude...) {
+		if len(r.VolumeAccessGroupNames) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func anyRuleMatches(rules []volumeRule, v volumeMetadata) bool {
+	for _, r := range rules {
+		if r.match(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r volumeRule) match(v volumeMetadata) bool {
+	if r.name != nil && !r.name.MatchString(v.Name) {
+		return false
+	}
+	if len(r.AccountIDs) > 0 && !containsInt(r.AccountIDs, v.AccountID) {
+		return false
+	}
+	if len(r.AccountNames) > 0 && !containsString(r.AccountNames, v.AccountName) {
+		return false
+	}
+	if len(r.VolumeAccessGroupIDs) > 0 && !intersectsInt(r.VolumeAccessGroupIDs, v.VolumeAccessGroupIDs) {
+		return false
+	}
+	if len(r.VolumeAccessGroupNames) > 0 && !intersectsString(r.VolumeAccessGroupNames, v.VolumeAccessGroupNames) {
+		return false
+	}
+	if len(r.Statuses) > 0 && !containsString(r.Statuses, v.Status) {
+		return false
+	}
+	for key, re := range r.attributes {
+		value, ok := attributeValue(v.Attributes, key)
+		if !ok || !re.MatchString(value) {
+			return false
+		}
+	}
+	return true
+}
+
+func attributeValue(attributes map[string]string, key string) (string, bool) {
+	for k, v := range attributes {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func containsInt(list []int, x int) bool {
+	for _, v := range list {
+		if v == x {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, x string) bool {
+	for _, v := range list {
+		if v == x {
+			return true
+		}
+	}
+	return false
+}
+
+func intersectsInt(a, b []int) bool {
+	for _, x := range b {
+		if containsInt(a, x) {
+			return true
+		}
+	}
+	return false
+}
+
+func intersectsString(a, b []string) bool {
+	for _, x := range b {
+		if containsString(a, x) {
+			return true
+		}
+	}
+	return false
+}
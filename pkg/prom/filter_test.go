@@ -0,0 +1,103 @@
+package prom_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mjavier2k/solidfire-exporter/pkg/prom"
+	"github.com/mjavier2k/solidfire-exporter/pkg/testutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Collect_VolumeFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter prom.VolumeFilterConfig
+		want   []string
+	}{
+		{
+			name:   "no rules",
+			filter: prom.VolumeFilterConfig{},
+			want:   []string{"1", "2"},
+		},
+		{
+			name:   "default exclude",
+			filter: prom.VolumeFilterConfig{Exclude: prom.DefaultVolumeExclude},
+			want:   []string{"1", "2"},
+		},
+		{
+			name:   "include by name",
+			filter: prom.VolumeFilterConfig{Include: []prom.VolumeRule{{Name: "volume1$"}}},
+			want:   []string{"1"},
+		},
+		{
+			name:   "exclude by volume access group",
+			filter: prom.VolumeFilterConfig{Exclude: []prom.VolumeRule{{VolumeAccessGroupIDs: []int{1}}}},
+			want:   []string{"2"},
+		},
+		{
+			name:   "exclude by volume access group name",
+			filter: prom.VolumeFilterConfig{Exclude: []prom.VolumeRule{{VolumeAccessGroupNames: []string{"example1"}}}},
+			want:   []string{"1", "2"},
+		},
+		{
+			name:   "include by account and attribute",
+			filter: prom.VolumeFilterConfig{Include: []prom.VolumeRule{{AccountIDs: []int{1}, Attributes: map[string]string{"owner_id": "^test_"}}}},
+			want:   []string{"1", "2"},
+		},
+		{
+			name:   "include by account name",
+			filter: prom.VolumeFilterConfig{Include: []prom.VolumeRule{{AccountNames: []string{"jamesw"}}}},
+			want:   []string{"1", "2"},
+		},
+		{
+			name:   "include by other account name",
+			filter: prom.VolumeFilterConfig{Include: []prom.VolumeRule{{AccountNames: []string{"jimmyd"}}}},
+			want:   nil,
+		},
+		{
+			name: "all fields of a rule must match",
+			filter: prom.VolumeFilterConfig{Exclude: []prom.VolumeRule{
+				{Name: "volume", Statuses: []string{"deleted"}},
+			}},
+			want: []string{"1", "2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector, err := prom.NewCollector(&prom.CollectorOpts{
+				Client:       newMockedClient(t, mockErrors{}),
+				Timeout:      time.Second,
+				VolumeFilter: tt.filter,
+			})
+			require.NoError(t, err)
+			r := prometheus.NewRegistry()
+			r.MustRegister(collector)
+
+			var got []string
+			for _, line := range testutils.PrometheusOutput(t, r, "solidfire") {
+				for _, prefix := range []string{"solidfire_volume_throttle{", "solidfire_volume_qos_throttle_percentage_count{"} {
+					if strings.HasPrefix(line, prefix) {
+						got = append(got, line)
+					}
+				}
+			}
+			assert.Len(t, got, 2*len(tt.want))
+			for _, id := range tt.want {
+				assert.Contains(t, strings.Join(got, "\n"), `volume_id="`+id+`"`)
+			}
+		})
+	}
+}
+
+func Test_NewCollector_InvalidVolumeFilter(t *testing.T) {
+	_, err := prom.NewCollector(&prom.CollectorOpts{
+		Client:       newMockedClient(t, mockErrors{}),
+		VolumeFilter: prom.VolumeFilterConfig{Exclude: []prom.VolumeRule{{Name: "("}}},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid volume exclude rule")
+}
@@ -0,0 +1,116 @@
+package prom
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	log "github.com/amoghe/distillog"
+	"github.com/mjavier2k/solidfire-exporter/pkg/solidfire"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// hardwareInfoConcurrency bounds the GetNodeHardwareInfo calls in flight, so
+// large clusters do not get one call per node at once on every scrape.
+const hardwareInfoConcurrency = 4
+
+// sensorValue parses the numeric part of an IPMI sensor reading such as
+// "5760" or "24 degrees C". Sensors without a reading are reported as "no
+// reading" or "na" and are skipped.
+func sensorValue(reading string) (float64, bool) {
+	fields := strings.Fields(reading)
+	if len(fields) == 0 {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+// collectHardware reports the fan, temperature and power supply sensors read
+// from the node BMCs, and the BMC and BIOS firmware versions of every node.
+// GetNodeHardwareInfo is called once per node, at most
+// hardwareInfoConcurrency at a time; a node whose call fails is logged and
+// left out. The nodes come from the listing shared with node_meta.
+func (c *SolidfireCollector) collectHardware(ctx context.Context, ch chan<- prometheus.Metric) error {
+	ipmi, err := c.client.GetIpmiInfo(ctx)
+	if err != nil {
+		return err
+	}
+	nodes, err := c.listAllNodes(ctx)
+	if err != nil {
+		return err
+	}
+	hardware := make([]*solidfire.GetNodeHardwareInfoResponse, len(nodes.Result.Nodes))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, hardwareInfoConcurrency)
+	for i, node := range nodes.Result.Nodes {
+		i, nodeID := i, node.NodeID
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			hw, err := c.client.GetNodeHardwareInfo(ctx, nodeID)
+			if err != nil {
+				log.Warningf("could not get the hardware info of node %d: %v", nodeID, err)
+				return
+			}
+			hardware[i] = &hw
+		}()
+	}
+	wg.Wait()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, sensor := range ipmi.Result.IpmiInfo.SensorReadings {
+		values := []string{strconv.Itoa(sensor.NodeID), c.nodeName(sensor.NodeID), sensor.SensorName}
+		switch sensor.SensorType {
+		case "Fan":
+			if v, ok := sensorValue(sensor.SensorReading); ok {
+				ch <- prometheus.MustNewConstMetric(
+					c.metrics.NodeFanSpeedRPM,
+					prometheus.GaugeValue,
+					v,
+					values...)
+			}
+		case "Temperature":
+			if v, ok := sensorValue(sensor.SensorReading); ok {
+				ch <- prometheus.MustNewConstMetric(
+					c.metrics.NodeTemperatureCelsius,
+					prometheus.GaugeValue,
+					v,
+					values...)
+			}
+		case "Power Supply":
+			ch <- prometheus.MustNewConstMetric(
+				c.metrics.NodePowerSupplyStatus,
+				prometheus.GaugeValue,
+				1,
+				append(values, sensor.SensorReading)...)
+		}
+	}
+
+	for i, node := range nodes.Result.Nodes {
+		if hardware[i] == nil {
+			continue
+		}
+		info := hardware[i].Result.NodeHardwareInfo
+		ch <- prometheus.MustNewConstMetric(
+			c.metrics.NodeHardwareInfo,
+			prometheus.GaugeValue,
+			1,
+			strconv.Itoa(node.NodeID),
+			c.nodeName(node.NodeID),
+			info.BmcFirmwareRevision,
+			info.BmcIpmiVersion,
+			info.BiosVendor,
+			info.BiosVersion,
+			info.BiosRevision,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package prom_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mjavier2k/solidfire-exporter/pkg/prom"
+	"github.com/mjavier2k/solidfire-exporter/pkg/solidfire"
+	"github.com/mjavier2k/solidfire-exporter/pkg/testutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Collect_Hardware(t *testing.T) {
+	collector, err := prom.NewCollector(&prom.CollectorOpts{
+		Client:     newMockedClient(t, mockErrors{}),
+		Timeout:    time.Second,
+		Collectors: map[string]bool{"hardware": true},
+	})
+	require.NoError(t, err)
+	r := prometheus.NewRegistry()
+	r.MustRegister(collector)
+	got := testutils.PrometheusOutput(t, r, "solidfire")
+
+	for _, want := range []string{
+		`solidfire_scrape_collector_success{collector="hardware"} 1`,
+		`solidfire_node_fan_speed_rpm{node_id="1",node_name="n01",sensor="SYS_FAN1"} 5760`,
+		`solidfire_node_temperature_celsius{node_id="1",node_name="n01",sensor="Inlet Temp"} 24`,
+		`solidfire_node_temperature_celsius{node_id="1",node_name="n01",sensor="Exhaust Temp"} 38`,
+		`solidfire_node_power_supply_status{node_id="1",node_name="n01",sensor="PS1 Status",status="Presence detected"} 1`,
+		`solidfire_node_power_supply_status{node_id="1",node_name="n01",sensor="PS2 Status",status="Failure detected"} 1`,
+		`solidfire_node_hardware_info{bios_revision="2.3",bios_vendor="American Megatrends Inc.",bios_version="NATP2.3",bmc_firmware_revision="3.25",bmc_ipmi_version="2.0",node_id="1",node_name="n01"} 1`,
+	} {
+		assert.Contains(t, got, want)
+	}
+	assert.NotContains(t, got, `sensor="SYS_FAN2"`)
+	assert.NotContains(t, got, `sensor="Voltage 1"`)
+}
+
+func Test_Collect_HardwareNodeFailure(t *testing.T) {
+	client := newMockedClient(t, mockErrors{})
+	nodes, err := client.ListAllNodes(context.Background())
+	require.NoError(t, err)
+	hw, err := client.GetNodeHardwareInfo(context.Background(), 1)
+	require.NoError(t, err)
+	second := nodes.Result.Nodes[0]
+	second.NodeID = 2
+	second.Name = "n02"
+	nodes.Result.Nodes = append(nodes.Result.Nodes, second)
+
+	var calls []*mock.Call
+	for _, call := range client.ExpectedCalls {
+		if call.Method != string(solidfire.RPCListAllNodes) && call.Method != string(solidfire.RPCGetNodeHardwareInfo) {
+			calls = append(calls, call)
+		}
+	}
+	client.ExpectedCalls = calls
+	client.On(string(solidfire.RPCListAllNodes), mock.Anything).Return(nodes, nil)
+	client.On(string(solidfire.RPCGetNodeHardwareInfo), mock.Anything, 1).Return(hw, nil)
+	client.On(string(solidfire.RPCGetNodeHardwareInfo), mock.Anything, 2).Return(solidfire.GetNodeHardwareInfoResponse{}, errors.New("connection refused"))
+
+	collector, err := prom.NewCollector(&prom.CollectorOpts{
+		Client:     client,
+		Timeout:    time.Second,
+		Collectors: map[string]bool{"hardware": true},
+	})
+	require.NoError(t, err)
+	r := prometheus.NewRegistry()
+	r.MustRegister(collector)
+	got := testutils.PrometheusOutput(t, r, "solidfire")
+
+	assert.Contains(t, got, `solidfire_scrape_collector_success{collector="hardware"} 1`)
+	assert.Contains(t, got, `solidfire_node_hardware_info{bios_revision="2.3",bios_vendor="American Megatrends Inc.",bios_version="NATP2.3",bmc_firmware_revision="3.25",bmc_ipmi_version="2.0",node_id="1",node_name="n01"} 1`)
+	for _, line := range got {
+		assert.NotRegexp(t, `^solidfire_node_hardware_info\{.*node_id="2"`, line)
+	}
+}
+
+func Test_Collect_HardwareBoundsConcurrency(t *testing.T) {
+	client := newMockedClient(t, mockErrors{})
+	nodes, err := client.ListAllNodes(context.Background())
+	require.NoError(t, err)
+	hw, err := client.GetNodeHardwareInfo(context.Background(), 1)
+	require.NoError(t, err)
+	first := nodes.Result.Nodes[0]
+	nodes.Result.Nodes = nil
+	for id := 1; id <= 12; id++ {
+		node := first
+		node.NodeID = id
+		nodes.Result.Nodes = append(nodes.Result.Nodes, node)
+	}
+
+	var calls []*mock.Call
+	for _, call := range client.ExpectedCalls {
+		if call.Method != string(solidfire.RPCListAllNodes) && call.Method != string(solidfire.RPCGetNodeHardwareInfo) {
+			calls = append(calls, call)
+		}
+	}
+	client.ExpectedCalls = calls
+	client.Calls = nil
+	var inFlight, maxInFlight int32
+	client.On(string(solidfire.RPCListAllNodes), mock.Anything).Return(nodes, nil)
+	client.On(string(solidfire.RPCGetNodeHardwareInfo), mock.Anything, mock.Anything).Return(hw, nil).Run(func(mock.Arguments) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+	})
+
+	collector, err := prom.NewCollector(&prom.CollectorOpts{
+		Client:     client,
+		Timeout:    time.Second,
+		Collectors: map[string]bool{"hardware": true},
+	})
+	require.NoError(t, err)
+	r := prometheus.NewRegistry()
+	r.MustRegister(collector)
+	got := testutils.PrometheusOutput(t, r, "solidfire")
+
+	assert.Contains(t, got, `solidfire_scrape_collector_success{collector="hardware"} 1`)
+	client.AssertNumberOfCalls(t, string(solidfire.RPCGetNodeHardwareInfo), 12)
+	// the node listing of node_meta is reused
+	client.AssertNumberOfCalls(t, string(solidfire.RPCListAllNodes), 1)
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(4))
+}
@@ -0,0 +1,97 @@
+package prom
+
+import (
+	"time"
+
+	log "github.com/amoghe/distillog"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// inventory holds the objects of one kind (volumes, nodes) from the latest
+// successful listing, by ID. Every refresh replaces the whole set, so deleted
+// objects are evicted and reused IDs never carry stale names. Callers hold the
+// collector lock.
+type inventory[T any] struct {
+	kind    string
+	objects map[int]inventoryObject[T]
+	listed  bool
+	added   uint64
+	removed uint64
+}
+
+type inventoryObject[T any] struct {
+	value     T
+	firstSeen time.Time
+	lastSeen  time.Time
+}
+
+func newInventory[T any](kind string) *inventory[T] {
+	return &inventory[T]{kind: kind, objects: make(map[int]inventoryObject[T])}
+}
+
+// replace swaps in the objects of a new listing. Objects keep their first-seen
+// time across refreshes; objects missing from the listing are evicted.
+func (i *inventory[T]) replace(values map[int]T, now time.Time) {
+	objects := make(map[int]inventoryObject[T], len(values))
+	var added uint64
+	for id, value := range values {
+		firstSeen := now
+		if old, ok := i.objects[id]; ok {
+			firstSeen = old.firstSeen
+		} else {
+			added++
+		}
+		objects[id] = inventoryObject[T]{value: value, firstSeen: firstSeen, lastSeen: now}
+	}
+	var removed uint64
+	for id, old := range i.objects {
+		if _, ok := objects[id]; !ok {
+			removed++
+			log.Debugf("%s %d removed from inventory, first seen %v, last seen %v", i.kind, id, old.firstSeen, old.lastSeen)
+		}
+	}
+	if i.listed {
+		i.added += added
+		i.removed += removed
+	}
+	i.objects = objects
+	i.listed = true
+}
+
+// values returns the objects of the latest listing, and false when there has
+// not been a successful listing yet.
+func (i *inventory[T]) values() (map[int]T, bool) {
+	values := make(map[int]T, len(i.objects))
+	for id, o := range i.objects {
+		values[id] = o.value
+	}
+	return values, i.listed
+}
+
+func (i *inventory[T]) get(id int) (T, bool) {
+	o, ok := i.objects[id]
+	return o.value, ok
+}
+
+func (i *inventory[T]) collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(
+		MetricDescriptions.InventoryObjects,
+		prometheus.GaugeValue,
+		float64(len(i.objects)),
+		i.kind,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		MetricDescriptions.InventoryChanges,
+		prometheus.CounterValue,
+		float64(i.added),
+		i.kind,
+		"added",
+	)
+	ch <- prometheus.MustNewConstMetric(
+		MetricDescriptions.InventoryChanges,
+		prometheus.CounterValue,
+		float64(i.removed),
+		i.kind,
+		"removed",
+	)
+}
@@ -0,0 +1,51 @@
+package prom_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mjavier2k/solidfire-exporter/pkg/prom"
+	"github.com/mjavier2k/solidfire-exporter/pkg/solidfire"
+	"github.com/mjavier2k/solidfire-exporter/pkg/testutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Collect_InventoryEviction(t *testing.T) {
+	var before, after solidfire.ListVolumesResponse
+	require.NoError(t, json.Unmarshal([]byte(`{"id":1,"result":{"volumes":[{"volumeID":1,"name":"vol1"},{"volumeID":2,"name":"vol2"}]}}`), &before))
+	require.NoError(t, json.Unmarshal([]byte(`{"id":1,"result":{"volumes":[{"volumeID":1,"name":"vol1"},{"volumeID":3,"name":"vol3"}]}}`), &after))
+	var stats solidfire.ListVolumeStatsResponse
+	require.NoError(t, json.Unmarshal([]byte(`{"id":1,"result":{"volumeStats":[{"volumeID":1},{"volumeID":2}]}}`), &stats))
+
+	client := new(testutils.MockSolidfireClient)
+	client.On("ListAllNodes", mock.Anything).Return(solidfire.ListAllNodesResponse{}, nil)
+	client.On("ListVolumes", mock.Anything).Return(before, nil).Once()
+	client.On("ListVolumes", mock.Anything).Return(after, nil)
+	client.On("ListVolumeStats", mock.Anything).Return(stats, nil)
+	client.On("ListAccounts", mock.Anything).Return(solidfire.ListAccountsResponse{}, nil)
+	client.On("APIVersion").Return("12.3")
+
+	collectors := map[string]bool{}
+	for _, name := range prom.CollectorNames() {
+		collectors[name] = name == "volume_stats"
+	}
+	collector, err := prom.NewCollector(&prom.CollectorOpts{Client: client, Timeout: time.Second, Collectors: collectors})
+	require.NoError(t, err)
+	r := prometheus.NewRegistry()
+	r.MustRegister(collector)
+
+	got := testutils.PrometheusOutput(t, r, "solidfire")
+	assert.Contains(t, got, `solidfire_inventory_objects{kind="volume"} 2`)
+	assert.Contains(t, got, `solidfire_volume_throttle{account_id="",volume_id="2",volume_name="vol2"} 0`)
+
+	got = testutils.PrometheusOutput(t, r, "solidfire")
+	assert.Contains(t, got, `solidfire_inventory_objects{kind="volume"} 2`)
+	assert.Contains(t, got, `solidfire_inventory_changes_total{change="added",kind="volume"} 1`)
+	assert.Contains(t, got, `solidfire_inventory_changes_total{change="removed",kind="volume"} 1`)
+	// volume 2 was deleted, its stale name must not be applied any more
+	assert.Contains(t, got, `solidfire_volume_throttle{account_id="",volume_id="2",volume_name=""} 0`)
+}
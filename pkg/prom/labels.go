@@ -0,0 +1,76 @@
+package prom
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// VolumeLabelsConfig adds optional labels to every per-volume metric. Every
+// label adds to the size of all volume series, so rarely used metadata is
+// better joined from solidfire_volume_info.
+type VolumeLabelsConfig struct {
+	// AccountName adds an account_name label resolved with ListAccounts.
+	AccountName bool `mapstructure:"account_name"`
+	// Attributes adds an attribute_<key> label per listed volume attribute.
+	Attributes []string `mapstructure:"attributes"`
+}
+
+var invalidLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// attributeLabel returns the label name of a volume attribute key.
+func attributeLabel(key string) string {
+	return "attribute_" + invalidLabelChars.ReplaceAllString(key, "_")
+}
+
+// labelNames returns the extra label names in the order of labelValues.
+func (l VolumeLabelsConfig) labelNames() ([]string, error) {
+	var names []string
+	seen := map[string]string{}
+	if l.AccountName {
+		names = append(names, "account_name")
+	}
+	for _, key := range l.Attributes {
+		name := attributeLabel(key)
+		if other, ok := seen[name]; ok {
+			return nil, fmt.Errorf("volume attributes %q and %q both map to label %s", other, key, name)
+		}
+		seen[name] = key
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// attributesLabelValue joins volume attributes as sorted key=value pairs, for
+// the attributes label of solidfire_volume_info.
+func attributesLabelValue(attributes map[string]string) string {
+	pairs := make([]string, 0, len(attributes))
+	for key, value := range attributes {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// idsLabelValue joins IDs with commas.
+func idsLabelValue(ids []int) string {
+	values := make([]string, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, strconv.Itoa(id))
+	}
+	return strings.Join(values, ",")
+}
+
+func (l VolumeLabelsConfig) labelValues(v volumeMetadata) []string {
+	var values []string
+	if l.AccountName {
+		values = append(values, v.AccountName)
+	}
+	for _, key := range l.Attributes {
+		value, _ := attributeValue(v.Attributes, key)
+		values = append(values, value)
+	}
+	return values
+}
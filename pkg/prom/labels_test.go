@@ -0,0 +1,55 @@
+package prom_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mjavier2k/solidfire-exporter/pkg/prom"
+	"github.com/mjavier2k/solidfire-exporter/pkg/solidfire"
+	"github.com/mjavier2k/solidfire-exporter/pkg/testutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Collect_VolumeLabels(t *testing.T) {
+	collector, err := prom.NewCollector(&prom.CollectorOpts{
+		Client:  newMockedClient(t, mockErrors{}),
+		Timeout: time.Second,
+		VolumeLabels: prom.VolumeLabelsConfig{
+			AccountName: true,
+			Attributes:  []string{"owner_id", "missing.key"},
+		},
+	})
+	require.NoError(t, err)
+	r := prometheus.NewRegistry()
+	r.MustRegister(collector)
+	got := testutils.PrometheusOutput(t, r, "solidfire")
+
+	assert.Contains(t, got, `solidfire_volume_throttle{account_id="test_owner",account_name="jamesw",attribute_missing_key="",attribute_owner_id="test_owner",volume_id="1",volume_name="test-volume1"} 0`)
+	assert.Contains(t, got, `solidfire_volume_info{access="readWrite",account_name="jamesw",attributes="owner_id=test_owner",block_size="4096",enable512e="true",iqn="iqn.2010-01.com.solidfire:1mhp.test-volume1.1",protection_scheme="singleHelix",scsi_eui_device_id="316d687000000001f47acc0100000000",scsi_naa_device_id="6f47acc100000000316d687000000001",slice_count="1",status="active",volume_access_groups="1",volume_account_id="1",volume_consistency_group_uuid="8918cf90-ccfc-4188-8438-744f891aa4fe",volume_id="1",volume_name="test-volume1",volume_uuid="763a0938-6906-4006-af96-ed42a3ea807e"} 1`)
+	assert.Contains(t, got, `solidfire_volume_provisioned_bytes{account_id="test_owner",account_name="jamesw",attribute_missing_key="",attribute_owner_id="test_owner",volume_id="1",volume_name="test-volume1"} 2.000683008e+09`)
+}
+
+func Test_NewCollector_DuplicateVolumeLabels(t *testing.T) {
+	_, err := prom.NewCollector(&prom.CollectorOpts{
+		Client:       newMockedClient(t, mockErrors{}),
+		VolumeLabels: prom.VolumeLabelsConfig{Attributes: []string{"a-b", "a.b"}},
+	})
+	assert.EqualError(t, err, `volume attributes "a-b" and "a.b" both map to label attribute_a_b`)
+}
+
+func Test_Collect_VolumeInfoWithoutAccounts(t *testing.T) {
+	collector, err := prom.NewCollector(&prom.CollectorOpts{
+		Client:  newMockedClient(t, mockErrors{solidfire.RPCListAccounts: errors.New("xPermissionDenied")}),
+		Timeout: time.Second,
+	})
+	require.NoError(t, err)
+	r := prometheus.NewRegistry()
+	r.MustRegister(collector)
+	got := testutils.PrometheusOutput(t, r, "solidfire")
+
+	assert.Contains(t, got, `solidfire_scrape_collector_success{collector="volume_meta"} 1`)
+	assert.Contains(t, got, `solidfire_volume_info{access="readWrite",account_name="",attributes="owner_id=test_owner",block_size="4096",enable512e="true",iqn="iqn.2010-01.com.solidfire:1mhp.test-volume1.1",protection_scheme="singleHelix",scsi_eui_device_id="316d687000000001f47acc0100000000",scsi_naa_device_id="6f47acc100000000316d687000000001",slice_count="1",status="active",volume_access_groups="1",volume_account_id="1",volume_consistency_group_uuid="8918cf90-ccfc-4188-8438-744f891aa4fe",volume_id="1",volume_name="test-volume1",volume_uuid="763a0938-6906-4006-af96-ed42a3ea807e"} 1`)
+}
@@ -6,7 +6,15 @@ import (
 
 type Descriptions struct {
 	// Solidfire Metric Descriptions
-	upDesc *prometheus.Desc
+	upDesc                  *prometheus.Desc
+	APIVersionInfo          *prometheus.Desc
+	ScrapeCollectorDuration *prometheus.Desc
+	ScrapeCollectorSuccess  *prometheus.Desc
+	LastSuccessfulPoll      *prometheus.Desc
+
+	// Inventory
+	InventoryObjects *prometheus.Desc
+	InventoryChanges *prometheus.Desc
 
 	// Volume Stats
 	VolumeActualIOPS              *prometheus.Desc
@@ -27,6 +35,25 @@ type Descriptions struct {
 	VolumeWriteLatencyTotal       *prometheus.Desc
 	VolumeWriteOpsTotal           *prometheus.Desc
 	VolumeStatsZeroBlocks         *prometheus.Desc
+	VolumeInfo                    *prometheus.Desc
+	VolumeCreatedTimestamp        *prometheus.Desc
+	VolumeLastIOTimestamp         *prometheus.Desc
+	VolumeProvisionedBytes        *prometheus.Desc
+	VolumeState                   *prometheus.Desc
+	VolumePurgeTimestamp          *prometheus.Desc
+
+	VolumeSnapshots               *prometheus.Desc
+	VolumeSnapshotBytes           *prometheus.Desc
+	VolumeSnapshotsExpired        *prometheus.Desc
+	VolumeSnapshotOldestTimestamp *prometheus.Desc
+	VolumeSnapshotNewestTimestamp *prometheus.Desc
+	VolumeSnapshotRemoteStatus    *prometheus.Desc
+
+	VolumePairInfo                            *prometheus.Desc
+	VolumePairState                           *prometheus.Desc
+	VolumePairSnapshotReplicationState        *prometheus.Desc
+	VolumePairLastReplicatedSnapshotTimestamp *prometheus.Desc
+	VolumeSnapMirrorReplicationEnabled        *prometheus.Desc
 
 	// ListVolumeQoSHistograms
 	VolumeQoSBelowMinIopsPercentagesHistogram      *prometheus.Desc
@@ -36,6 +63,14 @@ type Descriptions struct {
 	VolumeQoSThrottlePercentagesHistogram          *prometheus.Desc
 	VolumeQoSWriteBlockSizesHistogram              *prometheus.Desc
 
+	// ListVolumes QoS settings and ListQoSPolicies
+	VolumeQoSMinIOPS          *prometheus.Desc
+	VolumeQoSMaxIOPS          *prometheus.Desc
+	VolumeQoSBurstIOPS        *prometheus.Desc
+	VolumeQoSBurstTimeSeconds *prometheus.Desc
+	VolumeQoSCurve            *prometheus.Desc
+	VolumeQoSPolicyInfo       *prometheus.Desc
+
 	// Cluster Capacity
 	ClusterActiveBlockSpaceBytes             *prometheus.Desc
 	ClusterActiveSessions                    *prometheus.Desc
@@ -138,6 +173,9 @@ type Descriptions struct {
 	AccountCount           *prometheus.Desc
 	ClusterAdminCount      *prometheus.Desc
 	VolumeCount            *prometheus.Desc
+	DeletedVolumeCount     *prometheus.Desc
+	DeletedVolumeBytes     *prometheus.Desc
+	GroupSnapshotCount     *prometheus.Desc
 	VolumeAccessGroupCount *prometheus.Desc
 	VolumeAccessGroupLun   *prometheus.Desc
 	VirtualVolumeTasks     *prometheus.Desc
@@ -145,10 +183,46 @@ type Descriptions struct {
 	AsyncResultsActive     *prometheus.Desc
 	AsyncResults           *prometheus.Desc
 	MaxAsyncResultID       *prometheus.Desc
+
+	SchedulePaused           *prometheus.Desc
+	ScheduleVolumes          *prometheus.Desc
+	ScheduleLastRunSuccess   *prometheus.Desc
+	ScheduleLastRunTimestamp *prometheus.Desc
+	ScheduleNextRunTimestamp *prometheus.Desc
+
+	ClusterPairStatus         *prometheus.Desc
+	ClusterPairLatencySeconds *prometheus.Desc
+
+	SnapMirrorEndpointConnected                       *prometheus.Desc
+	SnapMirrorRelationshipMirrorState                 *prometheus.Desc
+	SnapMirrorRelationshipStatus                      *prometheus.Desc
+	SnapMirrorRelationshipHealthy                     *prometheus.Desc
+	SnapMirrorRelationshipLagSeconds                  *prometheus.Desc
+	SnapMirrorRelationshipLastTransferBytes           *prometheus.Desc
+	SnapMirrorRelationshipLastTransferDurationSeconds *prometheus.Desc
+
+	ClusterInfo           *prometheus.Desc
+	ClusterVersionInfo    *prometheus.Desc
+	ClusterUpgradePending *prometheus.Desc
+	ClusterNodeVersions   *prometheus.Desc
+	NodeVersionInfo       *prometheus.Desc
+
+	NodeClusterMaster    *prometheus.Desc
+	NodeEnsembleMember   *prometheus.Desc
+	ClusterEnsembleSize  *prometheus.Desc
+	ClusterMasterChanges *prometheus.Desc
+
+	NodeFanSpeedRPM        *prometheus.Desc
+	NodeTemperatureCelsius *prometheus.Desc
+	NodePowerSupplyStatus  *prometheus.Desc
+	NodeHardwareInfo       *prometheus.Desc
 }
 
-func NewMetricDescriptions(namespace string) *Descriptions {
+// NewMetricDescriptions builds the metric descriptions. extraVolumeLabels are
+// appended to the labels of every per-volume metric.
+func NewMetricDescriptions(namespace string, extraVolumeLabels ...string) *Descriptions {
 	var d Descriptions
+	volumeLabels := append([]string{"volume_id", "volume_name", "account_id"}, extraVolumeLabels...)
 
 	d.upDesc = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "up"),
@@ -156,129 +230,203 @@ func NewMetricDescriptions(namespace string) *Descriptions {
 		nil,
 		nil)
 
+	d.APIVersionInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "exporter", "api_version_info"),
+		"The Solidfire API version used by the exporter, from the endpoint or negotiated with the cluster.",
+		[]string{"api_version"},
+		nil,
+	)
+
+	d.ScrapeCollectorDuration = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "scrape", "collector_duration_seconds"),
+		"Duration of a collector scrape.",
+		[]string{"collector"},
+		nil,
+	)
+
+	d.ScrapeCollectorSuccess = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "scrape", "collector_success"),
+		"Whether a collector succeeded.",
+		[]string{"collector"},
+		nil,
+	)
+
+	d.LastSuccessfulPoll = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "last_successful_poll_timestamp_seconds"),
+		"Unix timestamp of the last successful background poll of a collector.",
+		[]string{"collector"},
+		nil,
+	)
+
+	d.VolumeInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_info"),
+		"Volume metadata. volume_account_id is the account the volume belongs to; the account_id label of other volume metrics is the owner_id attribute. attributes holds the volume attributes as sorted key=value pairs. Join on volume_id.",
+		[]string{
+			"volume_id", "volume_name", "volume_account_id", "account_name", "status",
+			"access", "block_size", "enable512e", "iqn", "scsi_naa_device_id", "scsi_eui_device_id", "volume_uuid",
+			"volume_consistency_group_uuid", "protection_scheme", "slice_count", "volume_access_groups", "attributes",
+		},
+		nil,
+	)
+
+	d.VolumeCreatedTimestamp = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_created_timestamp_seconds"),
+		"Unix timestamp of the creation of the volume.",
+		volumeLabels,
+		nil,
+	)
+
+	d.VolumeLastIOTimestamp = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_last_io_timestamp_seconds"),
+		"Unix timestamp of the last I/O to the volume. Not reported for volumes without I/O.",
+		volumeLabels,
+		nil,
+	)
+
+	d.VolumeProvisionedBytes = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_provisioned_bytes"),
+		"The provisioned size of the volume in bytes.",
+		volumeLabels,
+		nil,
+	)
+
+	d.InventoryObjects = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "inventory", "objects"),
+		"The number of objects of a kind (volume, node) in the latest listing.",
+		[]string{"kind"},
+		nil,
+	)
+
+	d.InventoryChanges = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "inventory", "changes_total"),
+		"The number of objects of a kind added to or removed from the inventory since its first listing.",
+		[]string{"kind", "change"},
+		nil,
+	)
+
 	d.VolumeActualIOPS = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "volume_actual_iops"),
 		"The current actual IOPS to the volume in the last 500 milliseconds",
-		[]string{"volume_id", "volume_name", "account_id"},
+		volumeLabels,
 		nil,
 	)
 
 	d.VolumeAverageIOPSizeBytes = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "volume_average_iop_size_bytes"),
 		"The average size in bytes of recent I/O to the volume in the last 500 milliseconds",
-		[]string{"volume_id", "volume_name", "account_id"},
+		volumeLabels,
 		nil,
 	)
 
 	d.VolumeBurstIOPSCredit = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "volume_burst_iops_credit"),
 		"The total number of IOP credits available to the user. When volumes are not using up to the configured maxIOPS, credits are accrued.",
-		[]string{"volume_id", "volume_name", "account_id"},
+		volumeLabels,
 		nil,
 	)
 
 	d.VolumeClientQueueDepth = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "volume_client_queue_depth"),
 		"The number of outstanding read and write operations to the volume.",
-		[]string{"volume_id", "volume_name", "account_id"},
+		volumeLabels,
 		nil,
 	)
 
 	d.VolumeLatencySeconds = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "volume_latency_seconds"),
 		"The average time, in seconds, to complete operations to the volume in the last 500 milliseconds. A '0' (zero) value means there is no I/O to the volume.",
-		[]string{"volume_id", "volume_name", "account_id"},
+		volumeLabels,
 		nil,
 	)
 
 	d.VolumeNonZeroBlocks = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "volume_non_zero_blocks"),
 		"The total number of 4KiB blocks that contain data after the last garbage collection operation has completed.",
-		[]string{"volume_id", "volume_name", "account_id"},
+		volumeLabels,
 		nil,
 	)
 
 	d.VolumeReadBytesTotal = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "volume_read_bytes_total"),
 		"The total cumulative bytes read from the volume since the creation of the volume.",
-		[]string{"volume_id", "volume_name", "account_id"},
+		volumeLabels,
 		nil,
 	)
 
 	d.VolumeReadLatencySecondsTotal = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "volume_read_latency_seconds_total"),
 		"The total time spent performing read operations from the volume",
-		[]string{"volume_id", "volume_name", "account_id"},
+		volumeLabels,
 		nil,
 	)
 
 	d.VolumeReadOpsTotal = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "volume_read_ops_total"),
 		"The total read operations to the volume since the creation of the volume.",
-		[]string{"volume_id", "volume_name", "account_id"},
+		volumeLabels,
 		nil,
 	)
 
 	d.VolumeThrottle = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "volume_throttle"),
 		"A floating value between 0 and 1 that represents how much the system is throttling clients below their maxIOPS because of rereplication of data, transient errors, and snapshots taken.",
-		[]string{"volume_id", "volume_name", "account_id"},
+		volumeLabels,
 		nil,
 	)
 
 	d.VolumeUnalignedReadsTotal = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "volume_unaligned_reads_total"),
 		"The total cumulative unaligned read operations to a volume since the creation of the volume.",
-		[]string{"volume_id", "volume_name", "account_id"},
+		volumeLabels,
 		nil,
 	)
 
 	d.VolumeUnalignedWritesTotal = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "volume_unaligned_writes_total"),
 		"The total cumulative unaligned write operations to a volume since the creation of the volume.",
-		[]string{"volume_id", "volume_name", "account_id"},
+		volumeLabels,
 		nil,
 	)
 
 	d.VolumeSizeBytes = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "volume_size_bytes"),
 		"Total provisioned capacity in bytes.",
-		[]string{"volume_id", "volume_name", "account_id"},
+		volumeLabels,
 		nil,
 	)
 
 	d.VolumeUtilization = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "volume_utilization"),
 		"A floating value that describes how much the client is using the volume. Value 0: The client is not using the volume. Value 1: The client is using their maximum. Value 1+: The client is using their burst.",
-		[]string{"volume_id", "volume_name", "account_id"},
+		volumeLabels,
 		nil,
 	)
 
 	d.VolumeWriteBytesTotal = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "volume_write_bytes_total"),
 		"The total cumulative bytes written to the volume since the creation of the volume.",
-		[]string{"volume_id", "volume_name", "account_id"},
+		volumeLabels,
 		nil,
 	)
 
 	d.VolumeWriteLatencyTotal = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "volume_write_latency_seconds_total"),
 		"The total time spent performing write operations to the volume",
-		[]string{"volume_id", "volume_name", "account_id"},
+		volumeLabels,
 		nil,
 	)
 
 	d.VolumeWriteOpsTotal = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "volume_write_ops_total"),
 		"The total cumulative write operations to the volume since the creation of the volume.",
-		[]string{"volume_id", "volume_name", "account_id"},
+		volumeLabels,
 		nil,
 	)
 
 	d.VolumeStatsZeroBlocks = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "volume_zero_blocks"),
 		"The total number of empty 4KiB blocks without data after the last round of garbage collection operation has completed.",
-		[]string{"volume_id", "volume_name", "account_id"},
+		volumeLabels,
 		nil,
 	)
 
@@ -527,42 +675,175 @@ func NewMetricDescriptions(namespace string) *Descriptions {
 	d.VolumeQoSBelowMinIopsPercentagesHistogram = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "volume_qos_below_min_iops_percentage"),
 		"Volume QoS Below minimum IOPS percentage",
-		[]string{"volume_id", "volume_name", "account_id"},
+		volumeLabels,
 		nil,
 	)
 
 	d.VolumeQoSMinToMaxIopsPercentagesHistogram = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "volume_qos_min_to_max_iops_percentage"),
 		"Volume QoS min to max IOPS percentage",
-		[]string{"volume_id", "volume_name", "account_id"},
+		volumeLabels,
 		nil,
 	)
 
 	d.VolumeQoSReadBlockSizesHistogram = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "volume_qos_read_block_sizes_bytes"),
 		"Volume QoS read block sizes",
-		[]string{"volume_id", "volume_name", "account_id"},
+		volumeLabels,
 		nil,
 	)
 
 	d.VolumeQoSTargetUtilizationPercentagesHistogram = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "volume_qos_target_utilization_percentage"),
 		"Volume QoS target utilization percentage",
-		[]string{"volume_id", "volume_name", "account_id"},
+		volumeLabels,
 		nil,
 	)
 
 	d.VolumeQoSThrottlePercentagesHistogram = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "volume_qos_throttle_percentage"),
 		"Volume QoS throttle percentage",
-		[]string{"volume_id", "volume_name", "account_id"},
+		volumeLabels,
 		nil,
 	)
 
 	d.VolumeQoSWriteBlockSizesHistogram = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "volume_qos_write_block_sizes_bytes"),
 		"Volume QoS write block sizes",
-		[]string{"volume_id", "volume_name", "account_id"},
+		volumeLabels,
+		nil,
+	)
+
+	d.VolumeState = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_state"),
+		"Whether the volume is active, idle (no I/O for volumes.idle_after), unmapped (no volume access group and no iSCSI sessions) or deleted and awaiting purge.",
+		append(append([]string{}, volumeLabels...), "state"),
+		nil,
+	)
+
+	d.VolumePurgeTimestamp = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_purge_timestamp_seconds"),
+		"Unix timestamp at which the deleted volume will be purged.",
+		volumeLabels,
+		nil,
+	)
+
+	d.VolumeSnapshots = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_snapshots"),
+		"The number of snapshots of the volume.",
+		volumeLabels,
+		nil,
+	)
+
+	d.VolumeSnapshotBytes = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_snapshot_bytes"),
+		"The total size of the snapshots of the volume.",
+		volumeLabels,
+		nil,
+	)
+
+	d.VolumeSnapshotsExpired = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_snapshots_expired"),
+		"The number of snapshots of the volume whose expiration time has passed.",
+		volumeLabels,
+		nil,
+	)
+
+	d.VolumeSnapshotOldestTimestamp = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_snapshot_oldest_timestamp_seconds"),
+		"Unix timestamp of the creation of the oldest snapshot of the volume.",
+		volumeLabels,
+		nil,
+	)
+
+	d.VolumeSnapshotNewestTimestamp = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_snapshot_newest_timestamp_seconds"),
+		"Unix timestamp of the creation of the newest snapshot of the volume.",
+		volumeLabels,
+		nil,
+	)
+
+	d.VolumeSnapshotRemoteStatus = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_snapshot_remote_status"),
+		"The number of remote replicas of the snapshots of the volume by replication status.",
+		append(append([]string{}, volumeLabels...), "remote_status"),
+		nil,
+	)
+
+	d.VolumePairInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_pair_info"),
+		"The pairing of the volume with a volume on a paired cluster, and its replication mode (Async, Sync or SnapshotsOnly).",
+		append(append([]string{}, volumeLabels...), "cluster_pair_id", "remote_volume_id", "remote_volume_name", "mode"),
+		nil,
+	)
+
+	d.VolumePairState = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_pair_state"),
+		"The remote replication state of the volume pair.",
+		append(append([]string{}, volumeLabels...), "cluster_pair_id", "state"),
+		nil,
+	)
+
+	d.VolumePairSnapshotReplicationState = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_pair_snapshot_replication_state"),
+		"The snapshot replication state of the volume pair.",
+		append(append([]string{}, volumeLabels...), "cluster_pair_id", "state"),
+		nil,
+	)
+
+	d.VolumePairLastReplicatedSnapshotTimestamp = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_pair_last_replicated_snapshot_timestamp_seconds"),
+		"Unix timestamp of the creation of the newest snapshot of the volume present on the paired cluster.",
+		append(append([]string{}, volumeLabels...), "cluster_pair_id"),
+		nil,
+	)
+
+	d.VolumeSnapMirrorReplicationEnabled = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_snapmirror_replication_enabled"),
+		"Whether SnapMirror replication is enabled on the volume.",
+		volumeLabels,
+		nil,
+	)
+
+	d.VolumeQoSMinIOPS = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_qos_min_iops"),
+		"The minimum number of sustained IOPS guaranteed to the volume.",
+		volumeLabels,
+		nil,
+	)
+
+	d.VolumeQoSMaxIOPS = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_qos_max_iops"),
+		"The maximum number of sustained IOPS allowed for the volume.",
+		volumeLabels,
+		nil,
+	)
+
+	d.VolumeQoSBurstIOPS = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_qos_burst_iops"),
+		"The maximum number of IOPS allowed for the volume in short bursts.",
+		volumeLabels,
+		nil,
+	)
+
+	d.VolumeQoSBurstTimeSeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_qos_burst_time_seconds"),
+		"The length of a burst of the volume.",
+		volumeLabels,
+		nil,
+	)
+
+	d.VolumeQoSCurve = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_qos_curve"),
+		"The IOPS cost of an IO of the given size, relative to 100 for a 4KiB IO.",
+		append(append([]string{}, volumeLabels...), "io_size_bytes"),
+		nil,
+	)
+
+	d.VolumeQoSPolicyInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_qos_policy_info"),
+		"The QoS policy assigned to the volume.",
+		append(append([]string{}, volumeLabels...), "qos_policy_id", "qos_policy_name"),
 		nil,
 	)
 
@@ -881,6 +1162,62 @@ func NewMetricDescriptions(namespace string) *Descriptions {
 		nil,
 	)
 
+	d.DeletedVolumeCount = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "cluster_deleted_volume_count"),
+		"The number of deleted volumes awaiting purge.",
+		nil,
+		nil,
+	)
+
+	d.DeletedVolumeBytes = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "cluster_deleted_volume_bytes"),
+		"The total provisioned size of the deleted volumes awaiting purge.",
+		nil,
+		nil,
+	)
+
+	d.GroupSnapshotCount = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "cluster_group_snapshot_count"),
+		"The number of group snapshots in the cluster.",
+		nil,
+		nil,
+	)
+
+	d.SchedulePaused = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "schedule_paused"),
+		"Whether the schedule is paused.",
+		[]string{"schedule_id", "schedule_name"},
+		nil,
+	)
+
+	d.ScheduleVolumes = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "schedule_volumes"),
+		"The number of volumes covered by the schedule.",
+		[]string{"schedule_id", "schedule_name"},
+		nil,
+	)
+
+	d.ScheduleLastRunSuccess = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "schedule_last_run_success"),
+		"Whether the last run of the schedule succeeded.",
+		[]string{"schedule_id", "schedule_name"},
+		nil,
+	)
+
+	d.ScheduleLastRunTimestamp = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "schedule_last_run_timestamp_seconds"),
+		"Unix timestamp of the start of the last run of the schedule.",
+		[]string{"schedule_id", "schedule_name"},
+		nil,
+	)
+
+	d.ScheduleNextRunTimestamp = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "schedule_next_run_timestamp_seconds"),
+		"Unix timestamp of the run of the schedule following its last run. A timestamp in the past means the schedule missed a run.",
+		[]string{"schedule_id", "schedule_name"},
+		nil,
+	)
+
 	d.AccountCount = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "cluster_account_count"),
 		"The total number of accounts in cluster",
@@ -939,5 +1276,159 @@ func NewMetricDescriptions(namespace string) *Descriptions {
 		nil,
 	)
 
+	d.ClusterPairStatus = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "cluster_pair_status"),
+		"The connection status of the cluster pair.",
+		[]string{"cluster_pair_id", "cluster_name", "status"},
+		nil,
+	)
+
+	d.ClusterPairLatencySeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "cluster_pair_latency_seconds"),
+		"The latency between the cluster and the paired cluster.",
+		[]string{"cluster_pair_id", "cluster_name"},
+		nil,
+	)
+
+	d.SnapMirrorEndpointConnected = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "snapmirror_endpoint_connected"),
+		"Whether the cluster is connected to the SnapMirror endpoint.",
+		[]string{"snapmirror_endpoint_id", "cluster_name", "management_ip"},
+		nil,
+	)
+
+	d.SnapMirrorRelationshipMirrorState = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "snapmirror_relationship_mirror_state"),
+		"The mirror state of the SnapMirror relationship (uninitialized, snapmirrored or broken-off).",
+		[]string{"snapmirror_endpoint_id", "relationship_id", "source_volume", "destination_volume", "state"},
+		nil,
+	)
+
+	d.SnapMirrorRelationshipStatus = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "snapmirror_relationship_status"),
+		"The status of the SnapMirror relationship, e.g. idle or transferring.",
+		[]string{"snapmirror_endpoint_id", "relationship_id", "source_volume", "destination_volume", "status"},
+		nil,
+	)
+
+	d.SnapMirrorRelationshipHealthy = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "snapmirror_relationship_healthy"),
+		"Whether the SnapMirror relationship is healthy.",
+		[]string{"snapmirror_endpoint_id", "relationship_id", "source_volume", "destination_volume"},
+		nil,
+	)
+
+	d.SnapMirrorRelationshipLagSeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "snapmirror_relationship_lag_seconds"),
+		"The time the destination of the SnapMirror relationship lags behind its source.",
+		[]string{"snapmirror_endpoint_id", "relationship_id", "source_volume", "destination_volume"},
+		nil,
+	)
+
+	d.SnapMirrorRelationshipLastTransferBytes = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "snapmirror_relationship_last_transfer_bytes"),
+		"The size of the last transfer of the SnapMirror relationship.",
+		[]string{"snapmirror_endpoint_id", "relationship_id", "source_volume", "destination_volume"},
+		nil,
+	)
+
+	d.SnapMirrorRelationshipLastTransferDurationSeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "snapmirror_relationship_last_transfer_duration_seconds"),
+		"The duration of the last transfer of the SnapMirror relationship.",
+		[]string{"snapmirror_endpoint_id", "relationship_id", "source_volume", "destination_volume"},
+		nil,
+	)
+
+	d.ClusterInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "cluster_info"),
+		"The identity of the cluster.",
+		[]string{"cluster_name", "uuid", "unique_id", "mvip", "svip", "encryption_at_rest_state", "software_encryption_at_rest_state", "replica_count", "ensemble"},
+		nil,
+	)
+
+	d.ClusterVersionInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "cluster_version_info"),
+		"The API and software version of the cluster, and the version it is upgrading to.",
+		[]string{"cluster_api_version", "cluster_version", "pending_cluster_version"},
+		nil,
+	)
+
+	d.ClusterUpgradePending = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "cluster_upgrade_pending"),
+		"Whether the pending cluster version differs from the cluster version.",
+		nil,
+		nil,
+	)
+
+	d.ClusterNodeVersions = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "cluster_node_versions"),
+		"The number of distinct software versions running on the nodes. More than 1 means the nodes run mismatched versions.",
+		nil,
+		nil,
+	)
+
+	d.NodeVersionInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "node_version_info"),
+		"The software version running on the node.",
+		[]string{"node_id", "node_name", "node_version"},
+		nil,
+	)
+
+	d.NodeClusterMaster = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "node_cluster_master"),
+		"Whether the node holds the cluster master role.",
+		[]string{"node_id", "node_name"},
+		nil,
+	)
+
+	d.NodeEnsembleMember = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "node_ensemble_member"),
+		"Whether the node is a member of the database ensemble.",
+		[]string{"node_id", "node_name"},
+		nil,
+	)
+
+	d.ClusterEnsembleSize = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "cluster_ensemble_size"),
+		"The number of nodes in the database ensemble.",
+		nil,
+		nil,
+	)
+
+	d.ClusterMasterChanges = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "cluster_master_changes_total"),
+		"The number of times the cluster master role moved to another node since the exporter started.",
+		nil,
+		nil,
+	)
+
+	d.NodeFanSpeedRPM = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "node_fan_speed_rpm"),
+		"The speed of the fan reported by the node BMC.",
+		[]string{"node_id", "node_name", "sensor"},
+		nil,
+	)
+
+	d.NodeTemperatureCelsius = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "node_temperature_celsius"),
+		"The temperature reported by the node BMC sensor.",
+		[]string{"node_id", "node_name", "sensor"},
+		nil,
+	)
+
+	d.NodePowerSupplyStatus = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "node_power_supply_status"),
+		"The status of the power supply reported by the node BMC, e.g. Presence detected or Failure detected.",
+		[]string{"node_id", "node_name", "sensor", "status"},
+		nil,
+	)
+
+	d.NodeHardwareInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "node_hardware_info"),
+		"The BMC and BIOS firmware versions of the node.",
+		[]string{"node_id", "node_name", "bmc_firmware_revision", "bmc_ipmi_version", "bios_vendor", "bios_version", "bios_revision"},
+		nil,
+	)
+
 	return &d
 }
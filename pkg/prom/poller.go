@@ -0,0 +1,186 @@
+package prom
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type PollerOpts struct {
+	Collector *SolidfireCollector
+	// Interval is the default time between two polls of a collector.
+	Interval time.Duration
+	// Intervals overrides Interval per collector name.
+	Intervals map[string]time.Duration
+}
+
+// Poller runs the collectors of a SolidfireCollector in the background, each
+// on its own interval, and serves the last good result of every collector on
+// scrape. Scrapes never call the Solidfire API.
+type Poller struct {
+	collector *SolidfireCollector
+	interval  time.Duration
+	intervals map[string]time.Duration
+
+	mu      sync.RWMutex
+	results map[string]*pollResult
+}
+
+// pollResult is the state of one collector: the metrics of its last
+// successful poll and the status of its latest poll.
+type pollResult struct {
+	metrics     []prometheus.Metric
+	duration    time.Duration
+	success     bool
+	lastSuccess time.Time
+}
+
+func NewPoller(opts *PollerOpts) (*Poller, error) {
+	if opts == nil || opts.Collector == nil {
+		return nil, fmt.Errorf("poller needs a collector")
+	}
+	if opts.Interval <= 0 {
+		return nil, fmt.Errorf("poll interval must be positive, got %v", opts.Interval)
+	}
+	known := map[string]bool{}
+	for _, s := range append(append([]subsystem{}, metadataSubsystems...), subsystems...) {
+		known[s.name] = true
+	}
+	for name, interval := range opts.Intervals {
+		if !known[name] {
+			return nil, fmt.Errorf("unknown collector %q in poll intervals", name)
+		}
+		if interval <= 0 {
+			return nil, fmt.Errorf("poll interval for %s must be positive, got %v", name, interval)
+		}
+	}
+	return &Poller{
+		collector: opts.Collector,
+		interval:  opts.Interval,
+		intervals: opts.Intervals,
+		results:   make(map[string]*pollResult),
+	}, nil
+}
+
+// Run polls until ctx is done. Metadata is polled once before the other
+// collectors start so their first results carry volume and node names.
+func (p *Poller) Run(ctx context.Context) {
+	for _, s := range metadataSubsystems {
+		p.poll(s)
+	}
+	var wg sync.WaitGroup
+	for _, s := range append(append([]subsystem{}, metadataSubsystems...), p.collector.subsystems...) {
+		s := s
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.loop(ctx, s, p.intervalFor(s.name))
+		}()
+	}
+	wg.Wait()
+}
+
+func (p *Poller) intervalFor(name string) time.Duration {
+	if interval, ok := p.intervals[name]; ok {
+		return interval
+	}
+	return p.interval
+}
+
+func (p *Poller) loop(ctx context.Context, s subsystem, interval time.Duration) {
+	if !isMetadata(s.name) {
+		p.poll(s)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.poll(s)
+		}
+	}
+}
+
+// poll runs one collector and stores its metrics. On failure the metrics of
+// the previous successful poll are kept.
+func (p *Poller) poll(s subsystem) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.collector.timeout)
+	defer cancel()
+
+	ch := make(chan prometheus.Metric)
+	done := make(chan struct{})
+	var metrics []prometheus.Metric
+	go func() {
+		for m := range ch {
+			metrics = append(metrics, m)
+		}
+		close(done)
+	}()
+	start := time.Now()
+	err := s.collect(p.collector, ctx, ch)
+	duration := time.Since(start)
+	close(ch)
+	<-done
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	r, ok := p.results[s.name]
+	if !ok {
+		r = &pollResult{}
+		p.results[s.name] = r
+	}
+	r.duration = duration
+	r.success = err == nil
+	if err != nil {
+		logCollectError(s.name, err)
+		return
+	}
+	r.metrics = metrics
+	r.lastSuccess = start
+}
+
+func isMetadata(name string) bool {
+	for _, s := range metadataSubsystems {
+		if s.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Poller) Describe(ch chan<- *prometheus.Desc) {
+	p.collector.Describe(ch)
+	ch <- MetricDescriptions.LastSuccessfulPoll
+}
+
+// Collect serves the cached results. solidfire_up is 1 when the latest poll
+// of any collector succeeded.
+func (p *Poller) Collect(ch chan<- prometheus.Metric) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	up := 0.0
+	for name, r := range p.results {
+		for _, m := range r.metrics {
+			ch <- m
+		}
+		collectStatus(ch, name, r.duration, r.success)
+		if !r.lastSuccess.IsZero() {
+			ch <- prometheus.MustNewConstMetric(
+				MetricDescriptions.LastSuccessfulPoll,
+				prometheus.GaugeValue,
+				float64(r.lastSuccess.UnixNano())/1e9,
+				name,
+			)
+		}
+		if r.success {
+			up = 1
+		}
+	}
+	p.collector.collectAPIVersion(ch)
+	ch <- prometheus.MustNewConstMetric(MetricDescriptions.upDesc, prometheus.GaugeValue, up)
+}
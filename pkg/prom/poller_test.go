@@ -0,0 +1,67 @@
+package prom_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mjavier2k/solidfire-exporter/pkg/prom"
+	"github.com/mjavier2k/solidfire-exporter/pkg/testutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Poller(t *testing.T) {
+	client := newMockedClient(t, mockErrors{})
+	collector, err := prom.NewCollector(&prom.CollectorOpts{Client: client, Timeout: time.Second})
+	require.NoError(t, err)
+	poller, err := prom.NewPoller(&prom.PollerOpts{
+		Collector: collector,
+		Interval:  time.Hour,
+		Intervals: map[string]time.Duration{"qos_histograms": 2 * time.Hour},
+	})
+	require.NoError(t, err)
+
+	r := prometheus.NewRegistry()
+	r.MustRegister(poller)
+	assert.Contains(t, testutils.PrometheusOutput(t, r, "solidfire"), "solidfire_up 0")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go poller.Run(ctx)
+
+	enabled := 0
+	for _, name := range prom.CollectorNames() {
+		if prom.CollectorEnabledByDefault(name) {
+			enabled++
+		}
+	}
+	var got string
+	require.Eventually(t, func() bool {
+		got = strings.Join(testutils.PrometheusOutput(t, r, "solidfire"), "\n")
+		// every enabled collector plus volume_meta and node_meta
+		return strings.Count(got, "solidfire_last_successful_poll_timestamp_seconds{") == enabled+2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Contains(t, got, "solidfire_up 1")
+	assert.Contains(t, got, `solidfire_volume_read_bytes_total{account_id="test_owner",volume_id="1",volume_name="test-volume1"}`)
+	assert.Contains(t, got, `solidfire_scrape_collector_success{collector="qos_histograms"} 1`)
+
+	// scrapes are served from the cache
+	calls := len(client.Calls)
+	testutils.PrometheusOutput(t, r, "solidfire")
+	assert.Equal(t, calls, len(client.Calls)-1, "only APIVersion should be called on scrape")
+}
+
+func Test_NewPoller_UnknownCollector(t *testing.T) {
+	collector, err := prom.NewCollector(&prom.CollectorOpts{Client: newMockedClient(t, mockErrors{})})
+	require.NoError(t, err)
+	_, err = prom.NewPoller(&prom.PollerOpts{
+		Collector: collector,
+		Interval:  time.Minute,
+		Intervals: map[string]time.Duration{"nope": time.Minute},
+	})
+	assert.EqualError(t, err, `unknown collector "nope" in poll intervals`)
+}
@@ -0,0 +1,280 @@
+package prom
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/amoghe/distillog"
+	"github.com/mjavier2k/solidfire-exporter/pkg/solidfire"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// scrapeTimeoutOffset is subtracted from the timeout advertised by Prometheus
+// so the probe can finish writing its response before the scrape is aborted.
+const scrapeTimeoutOffset = 500 * time.Millisecond
+
+const (
+	// DefaultProbeClientIdleTimeout is how long a probe client is kept after
+	// its last probe.
+	DefaultProbeClientIdleTimeout = 30 * time.Minute
+	// DefaultProbeMaxClients bounds the number of probe clients kept at once.
+	DefaultProbeMaxClients = 256
+)
+
+type ProbeHandlerOpts struct {
+	Modules map[string]solidfire.Module
+	Timeout time.Duration
+	Retry   solidfire.RetryPolicy
+	// Collectors enables or disables collectors by name, as for /metrics.
+	Collectors map[string]bool
+	// CacheTTLs enables response caching per method, shared by all probes of
+	// the same module and target.
+	CacheTTLs map[solidfire.RPC]time.Duration
+	// VolumeFilter selects the volumes of the per-volume metrics.
+	VolumeFilter VolumeFilterConfig
+	// VolumeLabels adds optional labels to the per-volume metrics.
+	VolumeLabels VolumeLabelsConfig
+	// IdleAfter is the time without I/O after which a volume is idle.
+	IdleAfter time.Duration
+	// ClientIdleTimeout evicts clients not used for this long. Defaults to
+	// DefaultProbeClientIdleTimeout.
+	ClientIdleTimeout time.Duration
+	// MaxClients bounds the number of cached clients, evicting the least
+	// recently used one. Defaults to DefaultProbeMaxClients.
+	MaxClients int
+}
+
+// ProbeHandler serves /probe?target=<mvip>&module=<name>. Each request gets a
+// fresh registry and collector, so inventory changes are not tracked across
+// probes; clients are reused per module and target until they are idle for
+// ClientIdleTimeout or pushed out by MaxClients. Only
+// configured modules are served, and a target must be allowed by the module's
+// Targets before any request is sent to it. Repeated collect[]=<name>
+// parameters restrict the probe to those collectors.
+type ProbeHandler struct {
+	modules    map[string]solidfire.Module
+	timeout    time.Duration
+	retry      solidfire.RetryPolicy
+	collectors map[string]bool
+	cacheTTLs  map[solidfire.RPC]time.Duration
+	volumes    VolumeFilterConfig
+	labels     VolumeLabelsConfig
+	idleAfter  time.Duration
+
+	clientIdleTimeout time.Duration
+	maxClients        int
+
+	mu      sync.Mutex
+	clients map[string]*probeClient
+}
+
+// probeClient is a cached client for one module and target, with the RPC
+// metrics it has accumulated across probes.
+type probeClient struct {
+	client     solidfire.Interface
+	rpcMetrics *RPCMetrics
+	lastUsed   time.Time
+	// ensemble tracks the cluster master of the target across probes.
+	ensemble *ensembleState
+}
+
+func NewProbeHandler(opts *ProbeHandlerOpts) *ProbeHandler {
+	if opts == nil {
+		opts = &ProbeHandlerOpts{}
+	}
+	h := &ProbeHandler{
+		modules:    opts.Modules,
+		timeout:    opts.Timeout,
+		retry:      opts.Retry,
+		collectors: opts.Collectors,
+		cacheTTLs:  opts.CacheTTLs,
+		volumes:    opts.VolumeFilter,
+		labels:     opts.VolumeLabels,
+		idleAfter:  opts.IdleAfter,
+
+		clientIdleTimeout: opts.ClientIdleTimeout,
+		maxClients:        opts.MaxClients,
+		clients:           make(map[string]*probeClient),
+	}
+	if h.clientIdleTimeout <= 0 {
+		h.clientIdleTimeout = DefaultProbeClientIdleTimeout
+	}
+	if h.maxClients <= 0 {
+		h.maxClients = DefaultProbeMaxClients
+	}
+	return h
+}
+
+func (h *ProbeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	target := params.Get("target")
+	if target == "" {
+		http.Error(w, "target parameter is missing", http.StatusBadRequest)
+		return
+	}
+	moduleName := params.Get("module")
+	if moduleName == "" {
+		moduleName = solidfire.DefaultModule
+	}
+	module, ok := h.modules[moduleName]
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown module %q", moduleName), http.StatusBadRequest)
+		return
+	}
+
+	endpoint, err := solidfire.EndpointForTarget(target, module.APIVersion)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !targetAllowed(module.Targets, endpoint) {
+		http.Error(w, fmt.Sprintf("target %q is not allowed for module %q", target, moduleName), http.StatusForbidden)
+		return
+	}
+
+	client, err := h.client(moduleName, module, endpoint)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	collectors, err := h.enabledCollectors(params["collect[]"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	collector, err := NewCollector(&CollectorOpts{Client: client.client, Timeout: h.scrapeTimeout(r), Collectors: collectors, VolumeFilter: h.volumes, VolumeLabels: h.labels, IdleAfter: h.idleAfter})
+	if err != nil {
+		log.Errorf("error initializing collector for target %s: %s\n", target, err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	collector.ensemble = client.ensemble
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collector, client.rpcMetrics)
+	promhttp.HandlerFor(registry, promhttp.HandlerOpts{}).ServeHTTP(w, r)
+}
+
+// targetAllowed reports whether the host of endpoint matches one of the
+// allowed hosts, host:port pairs or CIDR ranges. An empty list allows any
+// target.
+func targetAllowed(allowed []string, endpoint string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(u.Hostname())
+	for _, a := range allowed {
+		if strings.EqualFold(a, u.Host) || strings.EqualFold(a, u.Hostname()) {
+			return true
+		}
+		if _, cidr, err := net.ParseCIDR(a); err == nil && ip != nil && cidr.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// client returns the cached client for the module and endpoint, creating it if
+// needed. Idle clients are evicted first, then the least recently used ones
+// while the cache is full.
+func (h *ProbeHandler) client(moduleName string, module solidfire.Module, endpoint string) (*probeClient, error) {
+	key := moduleName + "\x00" + endpoint
+	now := time.Now()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if c, ok := h.clients[key]; ok {
+		c.lastUsed = now
+		return c, nil
+	}
+	for k, c := range h.clients {
+		if now.Sub(c.lastUsed) > h.clientIdleTimeout {
+			delete(h.clients, k)
+		}
+	}
+	for len(h.clients) >= h.maxClients {
+		var oldest string
+		for k, c := range h.clients {
+			if oldest == "" || c.lastUsed.Before(h.clients[oldest].lastUsed) {
+				oldest = k
+			}
+		}
+		delete(h.clients, oldest)
+	}
+	rpcMetrics := NewRPCMetrics()
+	c, err := solidfire.NewSolidfireClientWithOpts(&solidfire.ClientOpts{
+		Endpoint: endpoint,
+		Username: module.Username,
+		Password: module.Password,
+		Insecure: module.Insecure,
+		Timeout:  time.Duration(module.Timeout) * time.Second,
+		Retry:    h.retry,
+		Observer: rpcMetrics,
+	})
+	if err != nil {
+		return nil, err
+	}
+	pc := &probeClient{client: c, rpcMetrics: rpcMetrics, lastUsed: now, ensemble: &ensembleState{}}
+	if len(h.cacheTTLs) > 0 {
+		pc.client = solidfire.NewCachedClient(c, h.cacheTTLs, rpcMetrics)
+	}
+	h.clients[key] = pc
+	return pc, nil
+}
+
+// enabledCollectors narrows the configured collectors to the requested ones.
+// Requesting a collector that is disabled in the configuration is an error.
+func (h *ProbeHandler) enabledCollectors(requested []string) (map[string]bool, error) {
+	if len(requested) == 0 {
+		return h.collectors, nil
+	}
+	configured, err := enabledSubsystems(h.collectors)
+	if err != nil {
+		return nil, err
+	}
+	enabled := make(map[string]bool, len(subsystems))
+	for _, s := range subsystems {
+		enabled[s.name] = false
+	}
+	for _, name := range requested {
+		found := false
+		for _, s := range configured {
+			found = found || s.name == name
+		}
+		if !found {
+			return nil, fmt.Errorf("collector %q is unknown or disabled", name)
+		}
+		enabled[name] = true
+	}
+	return enabled, nil
+}
+
+// scrapeTimeout honours the X-Prometheus-Scrape-Timeout-Seconds header when it
+// is shorter than the configured collect timeout.
+func (h *ProbeHandler) scrapeTimeout(r *http.Request) time.Duration {
+	timeout := h.timeout
+	v := r.Header.Get("X-Prometheus-Scrape-Timeout-Seconds")
+	if v == "" {
+		return timeout
+	}
+	seconds, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return timeout
+	}
+	headerTimeout := time.Duration(seconds*float64(time.Second)) - scrapeTimeoutOffset
+	if headerTimeout > 0 && (timeout <= 0 || headerTimeout < timeout) {
+		return headerTimeout
+	}
+	return timeout
+}
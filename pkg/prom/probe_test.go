@@ -0,0 +1,183 @@
+package prom_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mjavier2k/solidfire-exporter/pkg/prom"
+	"github.com/mjavier2k/solidfire-exporter/pkg/solidfire"
+	"github.com/mjavier2k/solidfire-exporter/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFixtureServer answers every JSON-RPC call with the matching fixture,
+// echoing the request id.
+func newFixtureServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return newFixtureServerWith(t, nil)
+}
+
+// newFixtureServerWith is newFixtureServer, except that the calls for which
+// result returns true are answered with the returned result instead.
+func newFixtureServerWith(t *testing.T, result func(method solidfire.RPC) (interface{}, bool)) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body solidfire.RPCBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if result != nil {
+			if res, ok := result(body.Method); ok {
+				json.NewEncoder(w).Encode(map[string]interface{}{"id": body.ID, "result": res})
+				return
+			}
+		}
+		fixture, err := ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, body.Method))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		var resp map[string]json.RawMessage
+		if err := json.Unmarshal(fixture, &resp); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		resp["id"], _ = json.Marshal(body.ID)
+		json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func Test_ProbeHandler(t *testing.T) {
+	server := newFixtureServer(t)
+	defer server.Close()
+
+	handler := prom.NewProbeHandler(&prom.ProbeHandlerOpts{
+		Modules: map[string]solidfire.Module{
+			solidfire.DefaultModule: {Username: "user", Password: "pass", Timeout: 5, APIVersion: "11.3"},
+			"negotiate":             {Username: "user", Password: "pass", Timeout: 5},
+			"allowed":               {Username: "user", Password: "pass", Timeout: 5, APIVersion: "11.3", Targets: []string{"127.0.0.0/8"}},
+			"restricted":            {Username: "user", Password: "pass", Timeout: 5, APIVersion: "11.3", Targets: []string{"10.10.10.10", "192.168.0.0/16"}},
+		},
+		Timeout: 5 * time.Second,
+	})
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "missing target",
+			query:      "",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "target parameter is missing",
+		},
+		{
+			name:       "unknown module",
+			query:      "?target=" + server.URL + "&module=nope",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `unknown module "nope"`,
+		},
+		{
+			name:       "default module scrapes the target",
+			query:      "?target=" + server.URL,
+			wantStatus: http.StatusOK,
+			wantBody:   "solidfire_up 1",
+		},
+		{
+			name:       "target inside the module allowlist",
+			query:      "?target=" + server.URL + "&module=allowed",
+			wantStatus: http.StatusOK,
+			wantBody:   "solidfire_up 1",
+		},
+		{
+			name:       "target outside the module allowlist",
+			query:      "?target=" + server.URL + "&module=restricted",
+			wantStatus: http.StatusForbidden,
+			wantBody:   `is not allowed for module "restricted"`,
+		},
+		{
+			name:       "module without api_version negotiates it",
+			query:      "?target=" + server.URL + "&module=negotiate",
+			wantStatus: http.StatusOK,
+			wantBody:   `solidfire_exporter_api_version_info{api_version="12.3"} 1`,
+		},
+		{
+			name:       "probe reports RPC durations",
+			query:      "?target=" + server.URL,
+			wantStatus: http.StatusOK,
+			wantBody:   `solidfire_rpc_duration_seconds_count{method="ListVolumes"}`,
+		},
+		{
+			name:       "collect[] restricts the collectors",
+			query:      "?target=" + server.URL + "&collect[]=drives",
+			wantStatus: http.StatusOK,
+			wantBody:   "solidfire_drive_status",
+		},
+		{
+			name:       "collect[] with an unknown collector",
+			query:      "?target=" + server.URL + "&collect[]=nope",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `collector "nope" is unknown or disabled`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/probe"+tt.query, nil))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBody)
+		})
+	}
+}
+
+func Test_ProbeHandler_NoImplicitDefaultModule(t *testing.T) {
+	server := newFixtureServer(t)
+	defer server.Close()
+
+	handler := prom.NewProbeHandler(&prom.ProbeHandlerOpts{
+		Modules: map[string]solidfire.Module{
+			"readonly": {Username: "user", Password: "pass", Timeout: 5, APIVersion: "11.3"},
+		},
+		Timeout: 5 * time.Second,
+	})
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/probe?target="+server.URL, nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), `unknown module "default"`)
+}
+
+func Test_ProbeHandler_EvictsClients(t *testing.T) {
+	server := newFixtureServer(t)
+	defer server.Close()
+
+	handler := prom.NewProbeHandler(&prom.ProbeHandlerOpts{
+		Modules: map[string]solidfire.Module{
+			"a": {Username: "user", Password: "pass", Timeout: 5, APIVersion: "11.3"},
+			"b": {Username: "user", Password: "pass", Timeout: 5, APIVersion: "11.3"},
+		},
+		Timeout:    5 * time.Second,
+		MaxClients: 1,
+	})
+	probe := func(module string) string {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/probe?collect[]=drives&module="+module+"&target="+server.URL, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		return rec.Body.String()
+	}
+	// RPC metrics are gathered alongside the collector, so a probe reports
+	// the calls of the previous probes of the same client.
+	count := `solidfire_rpc_duration_seconds_count{method="ListDrives"}`
+
+	assert.NotContains(t, probe("a"), count)
+	assert.Contains(t, probe("a"), count+" 1", "client is reused")
+	probe("b")
+	assert.NotContains(t, probe("a"), count, "client of module a was evicted by module b")
+}
@@ -0,0 +1,110 @@
+package prom
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectFunc gathers the metrics of one subsystem.
+type collectFunc func(c *SolidfireCollector, ctx context.Context, ch chan<- prometheus.Metric) error
+
+type subsystem struct {
+	name           string
+	defaultEnabled bool
+	collect        collectFunc
+}
+
+// subsystems holds every registered collector in registration order. Volume
+// and node metadata are always collected and are not part of the registry.
+var subsystems []subsystem
+
+// metadataSubsystems run before the registered collectors, which label their
+// metrics with the volume and node names gathered here.
+var metadataSubsystems = []subsystem{
+	{name: "volume_meta", defaultEnabled: true, collect: (*SolidfireCollector).collectVolumeMeta},
+	{name: "node_meta", defaultEnabled: true, collect: (*SolidfireCollector).collectNodeMeta},
+}
+
+func registerCollector(name string, defaultEnabled bool, collect collectFunc) {
+	for _, s := range subsystems {
+		if s.name == name {
+			panic(fmt.Sprintf("collector %q registered twice", name))
+		}
+	}
+	subsystems = append(subsystems, subsystem{name: name, defaultEnabled: defaultEnabled, collect: collect})
+}
+
+func init() {
+	registerCollector("volume_stats", true, (*SolidfireCollector).collectVolumeStats)
+	registerCollector("cluster_capacity", true, (*SolidfireCollector).collectClusterCapacity)
+	registerCollector("faults", true, (*SolidfireCollector).collectClusterFaults)
+	registerCollector("node_stats", true, (*SolidfireCollector).collectClusterNodeStats)
+	registerCollector("qos_histograms", true, (*SolidfireCollector).collectVolumeQosHistograms)
+	registerCollector("cluster_stats", true, (*SolidfireCollector).collectClusterStats)
+	registerCollector("cluster_full_threshold", true, (*SolidfireCollector).collectClusterFullThreshold)
+	registerCollector("drives", true, (*SolidfireCollector).collectDriveDetails)
+	registerCollector("iscsi", true, (*SolidfireCollector).collectISCSISessions)
+	registerCollector("accounts", true, (*SolidfireCollector).collectAccounts)
+	registerCollector("initiators", true, (*SolidfireCollector).collectInitiators)
+	registerCollector("volume_access_groups", true, (*SolidfireCollector).collectVolumeAccessGroups)
+	registerCollector("virtual_volume_tasks", true, (*SolidfireCollector).collectVirtualVolumeTasks)
+	registerCollector("bulk_volume_jobs", true, (*SolidfireCollector).collectBulkVolumeJobs)
+	registerCollector("async_results", true, (*SolidfireCollector).collectAsyncResults)
+	registerCollector("volume_qos", true, (*SolidfireCollector).collectVolumeQoS)
+	registerCollector("volume_state", true, (*SolidfireCollector).collectVolumeState)
+	registerCollector("deleted_volumes", true, (*SolidfireCollector).collectDeletedVolumes)
+	registerCollector("snapshots", false, (*SolidfireCollector).collectSnapshots)
+	registerCollector("schedules", true, (*SolidfireCollector).collectSchedules)
+	registerCollector("replication", true, (*SolidfireCollector).collectReplication)
+	registerCollector("snapmirror", false, (*SolidfireCollector).collectSnapMirror)
+	registerCollector("cluster_info", true, (*SolidfireCollector).collectClusterInfo)
+	registerCollector("ensemble", true, (*SolidfireCollector).collectEnsemble)
+	registerCollector("hardware", false, (*SolidfireCollector).collectHardware)
+}
+
+// CollectorNames returns the names of all registered collectors, sorted.
+func CollectorNames() []string {
+	names := make([]string, 0, len(subsystems))
+	for _, s := range subsystems {
+		names = append(names, s.name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CollectorEnabledByDefault reports whether the named collector runs when it
+// is not explicitly enabled or disabled.
+func CollectorEnabledByDefault(name string) bool {
+	for _, s := range subsystems {
+		if s.name == name {
+			return s.defaultEnabled
+		}
+	}
+	return false
+}
+
+// enabledSubsystems resolves the enable switches against the registry. Names
+// missing from enabled fall back to their default; unknown names are an error.
+func enabledSubsystems(enabled map[string]bool) ([]subsystem, error) {
+	known := map[string]bool{}
+	var r []subsystem
+	for _, s := range subsystems {
+		known[s.name] = true
+		on, ok := enabled[s.name]
+		if !ok {
+			on = s.defaultEnabled
+		}
+		if on {
+			r = append(r, s)
+		}
+	}
+	for name := range enabled {
+		if !known[name] {
+			return nil, fmt.Errorf("unknown collector %q", name)
+		}
+	}
+	return r, nil
+}
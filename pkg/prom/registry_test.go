@@ -0,0 +1,54 @@
+package prom_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mjavier2k/solidfire-exporter/pkg/prom"
+	"github.com/mjavier2k/solidfire-exporter/pkg/testutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewCollector_UnknownCollector(t *testing.T) {
+	_, err := prom.NewCollector(&prom.CollectorOpts{
+		Client:     newMockedClient(t, mockErrors{}),
+		Collectors: map[string]bool{"nope": true},
+	})
+	assert.EqualError(t, err, `unknown collector "nope"`)
+}
+
+func Test_Collect_DisabledCollectors(t *testing.T) {
+	client := newMockedClient(t, mockErrors{})
+	collector, err := prom.NewCollector(&prom.CollectorOpts{
+		Client:  client,
+		Timeout: time.Second,
+		Collectors: map[string]bool{
+			"iscsi":          false,
+			"qos_histograms": false,
+			"volume_state":   false,
+		},
+	})
+	require.NoError(t, err)
+	r := prometheus.NewRegistry()
+	r.MustRegister(collector)
+	got := strings.Join(testutils.PrometheusOutput(t, r, "solidfire"), "\n")
+
+	assert.Contains(t, got, "solidfire_up 1")
+	assert.Contains(t, got, "solidfire_volume_read_bytes_total")
+	assert.NotContains(t, got, "solidfire_node_iscsi_sessions")
+	assert.NotContains(t, got, "solidfire_volume_qos_throttle_percentage")
+	client.AssertNotCalled(t, "ListISCSISessions", mock.Anything)
+	client.AssertNotCalled(t, "ListVolumeQoSHistograms", mock.Anything)
+}
+
+func Test_CollectorNames(t *testing.T) {
+	names := prom.CollectorNames()
+	for _, name := range []string{"volume_stats", "qos_histograms", "drives", "faults", "iscsi", "async_results"} {
+		assert.Contains(t, names, name)
+		assert.True(t, prom.CollectorEnabledByDefault(name), name)
+	}
+}
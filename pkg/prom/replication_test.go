@@ -0,0 +1,82 @@
+package prom_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mjavier2k/solidfire-exporter/pkg/prom"
+	"github.com/mjavier2k/solidfire-exporter/pkg/solidfire"
+	"github.com/mjavier2k/solidfire-exporter/pkg/testutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Collect_ReplicationSnapshots(t *testing.T) {
+	lastReplicated := `solidfire_volume_pair_last_replicated_snapshot_timestamp_seconds{account_id="test_owner",cluster_pair_id="1",volume_id="1",volume_name="test-volume1"} 1.6172352e+09`
+	tests := []struct {
+		name       string
+		collectors map[string]bool
+	}{
+		{
+			name:       "without the snapshots collector",
+			collectors: map[string]bool{"replication": true},
+		},
+		{
+			name:       "sharing ListSnapshots with the snapshots collector",
+			collectors: map[string]bool{"replication": true, "snapshots": true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newMockedClient(t, mockErrors{})
+			collector, err := prom.NewCollector(&prom.CollectorOpts{
+				Client:     client,
+				Timeout:    time.Second,
+				Collectors: tt.collectors,
+			})
+			require.NoError(t, err)
+			r := prometheus.NewRegistry()
+			r.MustRegister(collector)
+			got := testutils.PrometheusOutput(t, r, "solidfire")
+
+			assert.Contains(t, got, `solidfire_scrape_collector_success{collector="replication"} 1`)
+			assert.Contains(t, got, lastReplicated)
+			client.AssertNumberOfCalls(t, string(solidfire.RPCListSnapshots), 1)
+		})
+	}
+}
+
+func Test_Collect_ReplicationWithoutSnapshots(t *testing.T) {
+	collector, err := prom.NewCollector(&prom.CollectorOpts{
+		Client:     newMockedClient(t, mockErrors{solidfire.RPCListSnapshots: errors.New("connection refused")}),
+		Timeout:    time.Second,
+		Collectors: map[string]bool{"replication": true},
+	})
+	require.NoError(t, err)
+	r := prometheus.NewRegistry()
+	r.MustRegister(collector)
+	got := testutils.PrometheusOutput(t, r, "solidfire")
+
+	assert.Contains(t, got, `solidfire_scrape_collector_success{collector="replication"} 0`)
+	assert.Contains(t, got, `solidfire_volume_pair_info{account_id="test_owner",cluster_pair_id="1",mode="Async",remote_volume_id="8",remote_volume_name="test-volume1-dr",volume_id="1",volume_name="test-volume1"} 1`)
+	for _, line := range got {
+		assert.NotContains(t, line, "solidfire_volume_pair_last_replicated_snapshot_timestamp_seconds{")
+	}
+}
+
+func Test_Collect_ReplicationFilterUsesPairedVolumeName(t *testing.T) {
+	collector, err := prom.NewCollector(&prom.CollectorOpts{
+		Client:       newMockedClient(t, mockErrors{solidfire.RPCListVolumes: errors.New("error calling ListVolumes()")}),
+		Timeout:      time.Second,
+		Collectors:   map[string]bool{"replication": true},
+		VolumeFilter: prom.VolumeFilterConfig{Include: []prom.VolumeRule{{Name: "test-volume1"}}},
+	})
+	require.NoError(t, err)
+	r := prometheus.NewRegistry()
+	r.MustRegister(collector)
+	got := testutils.PrometheusOutput(t, r, "solidfire")
+
+	assert.Contains(t, got, `solidfire_volume_pair_info{account_id="",cluster_pair_id="1",mode="Async",remote_volume_id="8",remote_volume_name="test-volume1-dr",volume_id="1",volume_name="test-volume1"} 1`)
+}
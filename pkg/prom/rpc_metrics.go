@@ -0,0 +1,67 @@
+package prom
+
+import (
+	"time"
+
+	"github.com/mjavier2k/solidfire-exporter/pkg/solidfire"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// RPCMetrics records the latency and errors of every Solidfire API call, and
+// the hits and misses of the response cache. It is set as the
+// solidfire.Client Observer and solidfire.CachedClient CacheObserver and
+// registered next to the collector of the same client, so it carries the same
+// labels (e.g. sfcluster).
+type RPCMetrics struct {
+	duration *prometheus.HistogramVec
+	errors   *prometheus.CounterVec
+	cache    *prometheus.CounterVec
+}
+
+func NewRPCMetrics() *RPCMetrics {
+	return &RPCMetrics{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "solidfire",
+			Name:      "rpc_duration_seconds",
+			Help:      "Duration of Solidfire API calls, including failed attempts.",
+			Buckets:   prometheus.ExponentialBuckets(0.05, 2, 10),
+		}, []string{"method"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "solidfire",
+			Name:      "rpc_errors_total",
+			Help:      "Number of failed Solidfire API calls by error: the API error name, http_<code>, timeout, canceled, transport or invalid_response.",
+		}, []string{"method", "error"}),
+		cache: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "solidfire",
+			Name:      "rpc_cache_requests_total",
+			Help:      "Number of Solidfire API calls answered from the response cache (hit) or sent to the cluster (miss).",
+		}, []string{"method", "result"}),
+	}
+}
+
+func (m *RPCMetrics) ObserveRPC(method solidfire.RPC, duration time.Duration, err error) {
+	m.duration.WithLabelValues(string(method)).Observe(duration.Seconds())
+	if err != nil {
+		m.errors.WithLabelValues(string(method), solidfire.ErrorKind(err)).Inc()
+	}
+}
+
+func (m *RPCMetrics) ObserveCache(method solidfire.RPC, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.cache.WithLabelValues(string(method), result).Inc()
+}
+
+func (m *RPCMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.duration.Describe(ch)
+	m.errors.Describe(ch)
+	m.cache.Describe(ch)
+}
+
+func (m *RPCMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.duration.Collect(ch)
+	m.errors.Collect(ch)
+	m.cache.Collect(ch)
+}
@@ -0,0 +1,140 @@
+package prom
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	scheduleFrequencyTimeInterval = "Time Interval"
+	scheduleFrequencyDaysOfWeek   = "Days Of Week"
+	scheduleFrequencyDaysOfMonth  = "Days Of Month"
+)
+
+// schedule is the part of a ListSchedules schedule that determines its runs.
+type schedule struct {
+	frequency string
+	hours     int
+	minutes   int
+	weekdays  []time.Weekday
+	monthdays []int
+}
+
+// nextRun returns the first run of the schedule after the given time, usually
+// its last run. A next run in the past means the schedule missed a run. Time
+// Interval schedules run every hours and minutes, the others at hours:minutes
+// UTC on the listed days. It returns false for unknown frequencies.
+func (s schedule) nextRun(after time.Time) (time.Time, bool) {
+	after = after.UTC()
+	switch s.frequency {
+	case scheduleFrequencyTimeInterval:
+		interval := time.Duration(s.hours)*time.Hour + time.Duration(s.minutes)*time.Minute
+		if interval <= 0 {
+			return time.Time{}, false
+		}
+		return after.Add(interval), true
+	case scheduleFrequencyDaysOfWeek, scheduleFrequencyDaysOfMonth:
+		day := time.Date(after.Year(), after.Month(), after.Day(), s.hours, s.minutes, 0, 0, time.UTC)
+		// Every day of the month comes up within a year.
+		for i := 0; i <= 366; i++ {
+			run := day.AddDate(0, 0, i)
+			if run.After(after) && s.runsOn(run) {
+				return run, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func (s schedule) runsOn(t time.Time) bool {
+	if s.frequency == scheduleFrequencyDaysOfWeek {
+		for _, day := range s.weekdays {
+			if day == t.Weekday() {
+				return true
+			}
+		}
+		return false
+	}
+	return containsInt(s.monthdays, t.Day())
+}
+
+func (c *SolidfireCollector) collectSchedules(ctx context.Context, ch chan<- prometheus.Metric) error {
+	schedules, err := c.client.ListSchedules(ctx)
+	if err != nil {
+		return err
+	}
+	for _, sched := range schedules.Result.Schedules {
+		if sched.ToBeDeleted {
+			continue
+		}
+		values := []string{strconv.Itoa(sched.ScheduleID), sched.ScheduleName}
+
+		var paused float64 = 0
+		if sched.Paused {
+			paused = 1
+		}
+		ch <- prometheus.MustNewConstMetric(
+			c.metrics.SchedulePaused,
+			prometheus.GaugeValue,
+			paused,
+			values...)
+
+		volumes := len(sched.ScheduleInfo.Volumes)
+		if volumes == 0 && sched.ScheduleInfo.VolumeID != 0 {
+			volumes = 1
+		}
+		ch <- prometheus.MustNewConstMetric(
+			c.metrics.ScheduleVolumes,
+			prometheus.GaugeValue,
+			float64(volumes),
+			values...)
+
+		if sched.LastRunStatus != "" {
+			var success float64 = 0
+			if sched.LastRunStatus == "Success" {
+				success = 1
+			}
+			ch <- prometheus.MustNewConstMetric(
+				c.metrics.ScheduleLastRunSuccess,
+				prometheus.GaugeValue,
+				success,
+				values...)
+		}
+
+		// The next run follows the last run, or the starting date of
+		// schedules that never ran.
+		from, err := time.Parse(time.RFC3339, sched.LastRunTimeStarted)
+		if err == nil {
+			ch <- prometheus.MustNewConstMetric(
+				c.metrics.ScheduleLastRunTimestamp,
+				prometheus.GaugeValue,
+				float64(from.Unix()),
+				values...)
+		} else if from, err = time.Parse(time.RFC3339, sched.StartingDate); err != nil {
+			continue
+		}
+		if sched.Paused {
+			continue
+		}
+		s := schedule{
+			frequency: sched.Attributes.Frequency,
+			hours:     sched.Hours,
+			minutes:   sched.Minutes,
+			monthdays: sched.Monthdays,
+		}
+		for _, day := range sched.Weekdays {
+			s.weekdays = append(s.weekdays, time.Weekday(day.Day))
+		}
+		if next, ok := s.nextRun(from); ok {
+			ch <- prometheus.MustNewConstMetric(
+				c.metrics.ScheduleNextRunTimestamp,
+				prometheus.GaugeValue,
+				float64(next.Unix()),
+				values...)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package prom
+
+import (
+	"context"
+	"sync"
+)
+
+// scrapeResults holds API responses shared by the collectors of one scrape,
+// so a listing needed by several collectors is fetched once per scrape.
+type scrapeResults struct {
+	mu      sync.Mutex
+	results map[string]*scrapeResult
+}
+
+type scrapeResult struct {
+	once  sync.Once
+	value any
+	err   error
+}
+
+type scrapeResultsKey struct{}
+
+// withScrapeResults returns a context whose shared calls are made once.
+func withScrapeResults(ctx context.Context) context.Context {
+	return context.WithValue(ctx, scrapeResultsKey{}, &scrapeResults{results: map[string]*scrapeResult{}})
+}
+
+// shared returns the result of fetch, calling it at most once per scrape for
+// key; concurrent callers wait for the first one. Without a scrape in ctx, as
+// for background polls, fetch is called every time. Shared values must not be
+// modified.
+func shared[T any](ctx context.Context, key string, fetch func() (T, error)) (T, error) {
+	s, ok := ctx.Value(scrapeResultsKey{}).(*scrapeResults)
+	if !ok {
+		return fetch()
+	}
+	s.mu.Lock()
+	r, ok := s.results[key]
+	if !ok {
+		r = &scrapeResult{}
+		s.results[key] = r
+	}
+	s.mu.Unlock()
+	r.once.Do(func() {
+		r.value, r.err = fetch()
+	})
+	return r.value.(T), r.err
+}
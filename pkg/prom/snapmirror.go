@@ -0,0 +1,113 @@
+package prom
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/mjavier2k/solidfire-exporter/pkg/solidfire"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// snapMirrorVolumeName names a SnapMirror volume like ONTAP does, as
+// <vserver>:<volume>, or just the volume name for Element volumes.
+func snapMirrorVolumeName(v solidfire.SnapMirrorVolume) string {
+	if v.Vserver == "" {
+		return v.Name
+	}
+	return v.Vserver + ":" + v.Name
+}
+
+// collectSnapMirror reports the SnapMirror endpoints and relationships, and
+// which volumes have SnapMirror replication enabled.
+func (c *SolidfireCollector) collectSnapMirror(ctx context.Context, ch chan<- prometheus.Metric) error {
+	endpoints, err := c.client.ListSnapMirrorEndpoints(ctx)
+	if err != nil {
+		return err
+	}
+	relationships, err := c.client.ListSnapMirrorRelationships(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, endpoint := range endpoints.Result.SnapMirrorEndpoints {
+		var connected float64 = 0
+		if endpoint.IsConnected {
+			connected = 1
+		}
+		ch <- prometheus.MustNewConstMetric(
+			c.metrics.SnapMirrorEndpointConnected,
+			prometheus.GaugeValue,
+			connected,
+			strconv.Itoa(endpoint.SnapMirrorEndpointID),
+			endpoint.ClusterName,
+			endpoint.ManagementIP,
+		)
+	}
+
+	for _, r := range relationships.Result.SnapMirrorRelationships {
+		values := []string{
+			strconv.Itoa(r.SnapMirrorEndpointID),
+			r.SnapMirrorRelationshipID,
+			snapMirrorVolumeName(r.SourceVolume),
+			snapMirrorVolumeName(r.DestinationVolume),
+		}
+		ch <- prometheus.MustNewConstMetric(
+			c.metrics.SnapMirrorRelationshipMirrorState,
+			prometheus.GaugeValue,
+			1,
+			append(values, r.MirrorState)...)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.metrics.SnapMirrorRelationshipStatus,
+			prometheus.GaugeValue,
+			1,
+			append(values, r.RelationshipStatus)...)
+
+		var healthy float64 = 0
+		if r.IsHealthy {
+			healthy = 1
+		}
+		ch <- prometheus.MustNewConstMetric(
+			c.metrics.SnapMirrorRelationshipHealthy,
+			prometheus.GaugeValue,
+			healthy,
+			values...)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.metrics.SnapMirrorRelationshipLagSeconds,
+			prometheus.GaugeValue,
+			float64(r.Lagtime),
+			values...)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.metrics.SnapMirrorRelationshipLastTransferBytes,
+			prometheus.GaugeValue,
+			float64(r.LastTransferSize),
+			values...)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.metrics.SnapMirrorRelationshipLastTransferDurationSeconds,
+			prometheus.GaugeValue,
+			float64(r.LastTransferDuration),
+			values...)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	volumes, _ := c.volumes.values()
+	for _, metadata := range volumes {
+		if !c.volumeFilter.match(metadata) {
+			continue
+		}
+		var enabled float64 = 0
+		if metadata.EnableSnapMirrorReplication {
+			enabled = 1
+		}
+		ch <- prometheus.MustNewConstMetric(
+			c.metrics.VolumeSnapMirrorReplicationEnabled,
+			prometheus.GaugeValue,
+			enabled,
+			c.volumeLabelValues(metadata)...)
+	}
+	return nil
+}
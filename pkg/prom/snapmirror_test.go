@@ -0,0 +1,39 @@
+package prom_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mjavier2k/solidfire-exporter/pkg/prom"
+	"github.com/mjavier2k/solidfire-exporter/pkg/testutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Collect_SnapMirror(t *testing.T) {
+	collector, err := prom.NewCollector(&prom.CollectorOpts{
+		Client:     newMockedClient(t, mockErrors{}),
+		Timeout:    time.Second,
+		Collectors: map[string]bool{"snapmirror": true},
+	})
+	require.NoError(t, err)
+	r := prometheus.NewRegistry()
+	r.MustRegister(collector)
+	got := testutils.PrometheusOutput(t, r, "solidfire")
+
+	relationship := `destination_volume="svm_dr:test_volume1_dst",relationship_id="a2b3c4d5-e6f7-11eb-9a03-00a098d3f1a1",snapmirror_endpoint_id="1",source_volume="test-volume1"`
+	for _, want := range []string{
+		`solidfire_scrape_collector_success{collector="snapmirror"} 1`,
+		`solidfire_snapmirror_endpoint_connected{cluster_name="ontap-dr",management_ip="10.2.0.10",snapmirror_endpoint_id="1"} 1`,
+		`solidfire_snapmirror_relationship_mirror_state{` + relationship + `,state="snapmirrored"} 1`,
+		`solidfire_snapmirror_relationship_status{` + relationship + `,status="idle"} 1`,
+		`solidfire_snapmirror_relationship_healthy{` + relationship + `} 0`,
+		`solidfire_snapmirror_relationship_lag_seconds{` + relationship + `} 3725`,
+		`solidfire_snapmirror_relationship_last_transfer_bytes{` + relationship + `} 1.048576e+06`,
+		`solidfire_snapmirror_relationship_last_transfer_duration_seconds{` + relationship + `} 42`,
+		`solidfire_volume_snapmirror_replication_enabled{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 0`,
+	} {
+		assert.Contains(t, got, want)
+	}
+}
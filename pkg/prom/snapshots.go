@@ -0,0 +1,134 @@
+package prom
+
+import (
+	"context"
+	"time"
+
+	"github.com/mjavier2k/solidfire-exporter/pkg/solidfire"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// volumeSnapshots summarizes the snapshots of one volume.
+type volumeSnapshots struct {
+	// name is the volume name from the snapshots, for volumes missing from
+	// the volume listing, e.g. deleted volumes.
+	name    string
+	count   int
+	bytes   int64
+	expired int
+	oldest  time.Time
+	newest  time.Time
+	// remoteStatuses counts the replicas of the snapshots by remote status.
+	remoteStatuses map[string]int
+}
+
+// listSnapshots lists the snapshots once per scrape for the snapshots and
+// replication collectors.
+func (c *SolidfireCollector) listSnapshots(ctx context.Context) (solidfire.ListSnapshotsResponse, error) {
+	return shared(ctx, string(solidfire.RPCListSnapshots), func() (solidfire.ListSnapshotsResponse, error) {
+		return c.client.ListSnapshots(ctx)
+	})
+}
+
+// collectSnapshots reports the snapshots of every volume, and the group
+// snapshots of the cluster. Volumes without snapshots report a count of 0. A
+// failing ListGroupSnapshots only drops the group snapshot count.
+func (c *SolidfireCollector) collectSnapshots(ctx context.Context, ch chan<- prometheus.Metric) error {
+	snapshots, err := c.listSnapshots(ctx)
+	if err != nil {
+		return err
+	}
+	groupSnapshots, groupSnapshotsErr := c.client.ListGroupSnapshots(ctx)
+
+	now := time.Now()
+	byVolume := map[int]*volumeSnapshots{}
+	for _, snapshot := range snapshots.Result.Snapshots {
+		v, ok := byVolume[snapshot.VolumeID]
+		if !ok {
+			v = &volumeSnapshots{name: snapshot.VolumeName, remoteStatuses: map[string]int{}}
+			byVolume[snapshot.VolumeID] = v
+		}
+		v.count++
+		v.bytes += snapshot.TotalSize
+		if v.oldest.IsZero() || snapshot.CreateTime.Before(v.oldest) {
+			v.oldest = snapshot.CreateTime
+		}
+		if snapshot.CreateTime.After(v.newest) {
+			v.newest = snapshot.CreateTime
+		}
+		// expirationTime is null or "fifo" for snapshots that do not expire.
+		if expiration, err := time.Parse(time.RFC3339, snapshot.ExpirationTime); err == nil && expiration.Before(now) {
+			v.expired++
+		}
+		for _, remote := range snapshot.RemoteStatuses {
+			v.remoteStatuses[remote.RemoteStatus]++
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if volumes, ok := c.volumes.values(); ok {
+		for id := range volumes {
+			if _, ok := byVolume[id]; !ok {
+				byVolume[id] = &volumeSnapshots{}
+			}
+		}
+	}
+	for id, v := range byVolume {
+		metadata, ok := c.reportedVolumeNamed(id, v.name)
+		if !ok {
+			continue
+		}
+		values := c.volumeLabelValues(metadata)
+		ch <- prometheus.MustNewConstMetric(
+			c.metrics.VolumeSnapshots,
+			prometheus.GaugeValue,
+			float64(v.count),
+			values...)
+		if v.count == 0 {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.metrics.VolumeSnapshotBytes,
+			prometheus.GaugeValue,
+			float64(v.bytes),
+			values...)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.metrics.VolumeSnapshotsExpired,
+			prometheus.GaugeValue,
+			float64(v.expired),
+			values...)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.metrics.VolumeSnapshotOldestTimestamp,
+			prometheus.GaugeValue,
+			float64(v.oldest.Unix()),
+			values...)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.metrics.VolumeSnapshotNewestTimestamp,
+			prometheus.GaugeValue,
+			float64(v.newest.Unix()),
+			values...)
+
+		for status, count := range v.remoteStatuses {
+			ch <- prometheus.MustNewConstMetric(
+				c.metrics.VolumeSnapshotRemoteStatus,
+				prometheus.GaugeValue,
+				float64(count),
+				append(values, status)...)
+		}
+	}
+
+	if groupSnapshotsErr != nil {
+		return groupSnapshotsErr
+	}
+	ch <- prometheus.MustNewConstMetric(
+		c.metrics.GroupSnapshotCount,
+		prometheus.GaugeValue,
+		float64(len(groupSnapshots.Result.GroupSnapshots)),
+	)
+	return nil
+}
@@ -0,0 +1,79 @@
+package prom_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mjavier2k/solidfire-exporter/pkg/prom"
+	"github.com/mjavier2k/solidfire-exporter/pkg/solidfire"
+	"github.com/mjavier2k/solidfire-exporter/pkg/testutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Collect_Snapshots(t *testing.T) {
+	collector, err := prom.NewCollector(&prom.CollectorOpts{
+		Client:     newMockedClient(t, mockErrors{}),
+		Timeout:    time.Second,
+		Collectors: map[string]bool{"snapshots": true},
+	})
+	require.NoError(t, err)
+	r := prometheus.NewRegistry()
+	r.MustRegister(collector)
+	got := testutils.PrometheusOutput(t, r, "solidfire")
+
+	for _, want := range []string{
+		`solidfire_scrape_collector_success{collector="snapshots"} 1`,
+		`solidfire_cluster_group_snapshot_count 1`,
+		`solidfire_volume_snapshots{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 2`,
+		`solidfire_volume_snapshot_bytes{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 4.001366016e+09`,
+		`solidfire_volume_snapshots_expired{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 1`,
+		`solidfire_volume_snapshot_oldest_timestamp_seconds{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 1.6172352e+09`,
+		`solidfire_volume_snapshot_newest_timestamp_seconds{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 1.6184448e+09`,
+		`solidfire_volume_snapshot_remote_status{account_id="test_owner",remote_status="Present",volume_id="1",volume_name="test-volume1"} 1`,
+		`solidfire_volume_snapshot_remote_status{account_id="test_owner",remote_status="Syncing",volume_id="1",volume_name="test-volume1"} 1`,
+		`solidfire_volume_snapshots{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 1`,
+		`solidfire_volume_snapshots_expired{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 0`,
+	} {
+		assert.Contains(t, got, want)
+	}
+}
+
+func Test_Collect_SnapshotsWithoutGroupSnapshots(t *testing.T) {
+	collector, err := prom.NewCollector(&prom.CollectorOpts{
+		Client:     newMockedClient(t, mockErrors{solidfire.RPCListGroupSnapshots: errors.New("xPermissionDenied")}),
+		Timeout:    time.Second,
+		Collectors: map[string]bool{"snapshots": true},
+	})
+	require.NoError(t, err)
+	r := prometheus.NewRegistry()
+	r.MustRegister(collector)
+	got := testutils.PrometheusOutput(t, r, "solidfire")
+
+	assert.Contains(t, got, `solidfire_scrape_collector_success{collector="snapshots"} 0`)
+	assert.Contains(t, got, `solidfire_volume_snapshots{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 2`)
+	for _, line := range got {
+		assert.False(t, strings.HasPrefix(line, "solidfire_cluster_group_snapshot_count"), line)
+	}
+}
+
+func Test_Collect_SnapshotsFilterUsesSnapshotVolumeName(t *testing.T) {
+	collector, err := prom.NewCollector(&prom.CollectorOpts{
+		Client:       newMockedClient(t, mockErrors{solidfire.RPCListVolumes: errors.New("error calling ListVolumes()")}),
+		Timeout:      time.Second,
+		Collectors:   map[string]bool{"snapshots": true},
+		VolumeFilter: prom.VolumeFilterConfig{Include: []prom.VolumeRule{{Name: "test-volume1"}}},
+	})
+	require.NoError(t, err)
+	r := prometheus.NewRegistry()
+	r.MustRegister(collector)
+	got := testutils.PrometheusOutput(t, r, "solidfire")
+
+	assert.Contains(t, got, `solidfire_volume_snapshots{account_id="",volume_id="1",volume_name="test-volume1"} 2`)
+	for _, line := range got {
+		assert.NotContains(t, line, `volume_id="2"`)
+	}
+}
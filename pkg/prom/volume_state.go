@@ -0,0 +1,206 @@
+package prom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mjavier2k/solidfire-exporter/pkg/solidfire"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	VolumeStateActive   = "active"
+	VolumeStateIdle     = "idle"
+	VolumeStateUnmapped = "unmapped"
+	VolumeStateDeleted  = "deleted"
+)
+
+var possibleVolumeStates = []string{VolumeStateActive, VolumeStateIdle, VolumeStateUnmapped, VolumeStateDeleted}
+
+// VolumeReport is the state of every reported volume from the latest run of
+// the volume_state collector.
+type VolumeReport struct {
+	Generated time.Time           `json:"generated"`
+	Volumes   []VolumeReportEntry `json:"volumes"`
+}
+
+type VolumeReportEntry struct {
+	VolumeID           int        `json:"volumeID"`
+	Name               string     `json:"name"`
+	AccountID          int        `json:"accountID"`
+	AccountName        string     `json:"accountName"`
+	State              string     `json:"state"`
+	Status             string     `json:"status"`
+	TotalSize          int64      `json:"totalSize"`
+	CreateTime         time.Time  `json:"createTime"`
+	LastAccessTimeIO   *time.Time `json:"lastAccessTimeIO"`
+	VolumeAccessGroups []int      `json:"volumeAccessGroups"`
+	ISCSISessions      int        `json:"iscsiSessions"`
+}
+
+// volumeState classifies a volume. Deleted volumes awaiting purge come first,
+// then volumes without a volume access group and without iSCSI sessions, then
+// volumes without I/O for idleAfter. Volumes that never had I/O count from
+// their creation.
+func volumeState(v volumeMetadata, sessions int, now time.Time, idleAfter time.Duration) string {
+	if v.Status == "deleted" {
+		return VolumeStateDeleted
+	}
+	if len(v.VolumeAccessGroupIDs) == 0 && sessions == 0 {
+		return VolumeStateUnmapped
+	}
+	lastIO := v.LastAccessTimeIO
+	if lastIO.IsZero() {
+		lastIO = v.CreateTime
+	}
+	if now.Sub(lastIO) > idleAfter {
+		return VolumeStateIdle
+	}
+	return VolumeStateActive
+}
+
+// collectVolumeState reports the state of every volume. Without the deleted
+// volume listing the other volumes are still reported, and the error is
+// returned afterwards.
+func (c *SolidfireCollector) collectVolumeState(ctx context.Context, ch chan<- prometheus.Metric) error {
+	iscsiSessions, err := c.listISCSISessions(ctx)
+	if err != nil {
+		return err
+	}
+	sessions := map[int]int{}
+	for _, session := range iscsiSessions.Result.Sessions {
+		sessions[session.VolumeID]++
+	}
+	_, deleted, deletedErr := c.listDeletedVolumeMeta(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	volumes, ok := c.volumes.values()
+	if !ok {
+		return fmt.Errorf("no volume listing available")
+	}
+	for id, metadata := range deleted {
+		volumes[id] = metadata
+	}
+	now := time.Now()
+	report := VolumeReport{Generated: now}
+	for id, metadata := range volumes {
+		if !c.volumeFilter.match(metadata) {
+			continue
+		}
+		state := volumeState(metadata, sessions[id], now, c.idleAfter)
+		values := c.volumeLabelValues(metadata)
+		for _, s := range possibleVolumeStates {
+			var stateValue float64 = 0
+			if s == state {
+				stateValue = 1
+			}
+			ch <- prometheus.MustNewConstMetric(
+				c.metrics.VolumeState,
+				prometheus.GaugeValue,
+				stateValue,
+				append(values, s)...)
+		}
+
+		entry := VolumeReportEntry{
+			VolumeID:           id,
+			Name:               metadata.Name,
+			AccountID:          metadata.AccountID,
+			AccountName:        metadata.AccountName,
+			State:              state,
+			Status:             metadata.Status,
+			TotalSize:          metadata.TotalSize,
+			CreateTime:         metadata.CreateTime,
+			VolumeAccessGroups: metadata.VolumeAccessGroupIDs,
+			ISCSISessions:      sessions[id],
+		}
+		if !metadata.LastAccessTimeIO.IsZero() {
+			lastIO := metadata.LastAccessTimeIO
+			entry.LastAccessTimeIO = &lastIO
+		}
+		report.Volumes = append(report.Volumes, entry)
+	}
+	sort.Slice(report.Volumes, func(i, j int) bool { return report.Volumes[i].VolumeID < report.Volumes[j].VolumeID })
+	c.volumeReport = report
+	return deletedErr
+}
+
+// VolumeReport returns the volume states from the latest run of the
+// volume_state collector.
+func (c *SolidfireCollector) VolumeReport() VolumeReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.volumeReport
+}
+
+// VolumeReportHandler serves the volume reports of several collectors as JSON,
+// keyed by cluster name. ?cluster=<name> and ?state=<state> narrow the report.
+type VolumeReportHandler struct {
+	mu         sync.Mutex
+	collectors map[string]*SolidfireCollector
+}
+
+func NewVolumeReportHandler(collectors map[string]*SolidfireCollector) *VolumeReportHandler {
+	return &VolumeReportHandler{collectors: collectors}
+}
+
+// Add serves the report of a cluster added after the handler was created.
+func (h *VolumeReportHandler) Add(name string, collector *SolidfireCollector) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.collectors[name] = collector
+}
+
+func (h *VolumeReportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	cluster := params.Get("cluster")
+	state := params.Get("state")
+	if state != "" && !containsString(possibleVolumeStates, state) {
+		http.Error(w, fmt.Sprintf("unknown state %q, need one of %v", state, possibleVolumeStates), http.StatusBadRequest)
+		return
+	}
+	h.mu.Lock()
+	collectors := make(map[string]*SolidfireCollector, len(h.collectors))
+	for name, collector := range h.collectors {
+		collectors[name] = collector
+	}
+	h.mu.Unlock()
+	reports := map[string]VolumeReport{}
+	for name, collector := range collectors {
+		if cluster != "" && name != cluster {
+			continue
+		}
+		report := collector.VolumeReport()
+		if state != "" {
+			var volumes []VolumeReportEntry
+			for _, v := range report.Volumes {
+				if v.State == state {
+					volumes = append(volumes, v)
+				}
+			}
+			report.Volumes = volumes
+		}
+		reports[name] = report
+	}
+	if cluster != "" && len(reports) == 0 {
+		http.Error(w, fmt.Sprintf("unknown cluster %q", cluster), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(reports); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// idleAfterOrDefault returns the configured idle period of volumes.
+func idleAfterOrDefault(idleAfter time.Duration) time.Duration {
+	if idleAfter <= 0 {
+		return solidfire.DefaultVolumesIdleAfter
+	}
+	return idleAfter
+}
@@ -0,0 +1,128 @@
+package prom_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mjavier2k/solidfire-exporter/pkg/prom"
+	"github.com/mjavier2k/solidfire-exporter/pkg/solidfire"
+	"github.com/mjavier2k/solidfire-exporter/pkg/testutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_VolumeReportHandler(t *testing.T) {
+	collector, err := prom.NewCollector(&prom.CollectorOpts{
+		Client:  newMockedClient(t, mockErrors{}),
+		Timeout: time.Second,
+	})
+	require.NoError(t, err)
+	r := prometheus.NewRegistry()
+	r.MustRegister(collector)
+	testutils.PrometheusOutput(t, r, "solidfire")
+
+	handler := prom.NewVolumeReportHandler(map[string]*prom.SolidfireCollector{solidfire.LocalCluster: collector})
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantStates map[int]string
+	}{
+		{
+			name:       "all volumes",
+			wantStatus: http.StatusOK,
+			wantStates: map[int]string{1: prom.VolumeStateIdle, 2: prom.VolumeStateUnmapped, 3: prom.VolumeStateDeleted},
+		},
+		{
+			name:       "by state",
+			query:      "?state=unmapped",
+			wantStatus: http.StatusOK,
+			wantStates: map[int]string{2: prom.VolumeStateUnmapped},
+		},
+		{
+			name:       "unknown state",
+			query:      "?state=nope",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown cluster",
+			query:      "?cluster=nope",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/report/volumes"+tt.query, nil))
+			require.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStates == nil {
+				return
+			}
+			reports := map[string]prom.VolumeReport{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &reports))
+			got := map[int]string{}
+			for _, v := range reports[solidfire.LocalCluster].Volumes {
+				got[v.VolumeID] = v.State
+			}
+			assert.Equal(t, tt.wantStates, got)
+		})
+	}
+}
+
+func Test_Collect_VolumeStateSharesISCSISessions(t *testing.T) {
+	client := newMockedClient(t, mockErrors{})
+	collector, err := prom.NewCollector(&prom.CollectorOpts{
+		Client:     client,
+		Timeout:    time.Second,
+		Collectors: map[string]bool{"iscsi": true, "volume_state": true},
+	})
+	require.NoError(t, err)
+	r := prometheus.NewRegistry()
+	r.MustRegister(collector)
+	got := testutils.PrometheusOutput(t, r, "solidfire")
+
+	assert.Contains(t, got, `solidfire_scrape_collector_success{collector="iscsi"} 1`)
+	assert.Contains(t, got, `solidfire_scrape_collector_success{collector="volume_state"} 1`)
+	client.AssertNumberOfCalls(t, string(solidfire.RPCListISCSISessions), 1)
+}
+
+func Test_Collect_SharesDeletedVolumesAndAccounts(t *testing.T) {
+	client := newMockedClient(t, mockErrors{})
+	collector, err := prom.NewCollector(&prom.CollectorOpts{
+		Client:       client,
+		Timeout:      time.Second,
+		Collectors:   map[string]bool{"accounts": true, "deleted_volumes": true, "volume_state": true},
+		VolumeLabels: prom.VolumeLabelsConfig{AccountName: true},
+	})
+	require.NoError(t, err)
+	r := prometheus.NewRegistry()
+	r.MustRegister(collector)
+	got := testutils.PrometheusOutput(t, r, "solidfire")
+
+	assert.Contains(t, got, `solidfire_cluster_deleted_volume_count 1`)
+	assert.Contains(t, got, `solidfire_scrape_collector_success{collector="volume_state"} 1`)
+	client.AssertNumberOfCalls(t, string(solidfire.RPCListDeletedVolumes), 1)
+	client.AssertNumberOfCalls(t, string(solidfire.RPCListAccounts), 1)
+}
+
+func Test_Collect_VolumeStateWithoutDeletedVolumes(t *testing.T) {
+	collector, err := prom.NewCollector(&prom.CollectorOpts{
+		Client:     newMockedClient(t, mockErrors{solidfire.RPCListDeletedVolumes: errors.New("connection refused")}),
+		Timeout:    time.Second,
+		Collectors: map[string]bool{"volume_state": true},
+	})
+	require.NoError(t, err)
+	r := prometheus.NewRegistry()
+	r.MustRegister(collector)
+	got := testutils.PrometheusOutput(t, r, "solidfire")
+
+	assert.Contains(t, got, `solidfire_scrape_collector_success{collector="volume_state"} 0`)
+	assert.Contains(t, got, `solidfire_volume_state{account_id="test_owner",state="idle",volume_id="1",volume_name="test-volume1"} 1`)
+	assert.Contains(t, got, `solidfire_volume_state{account_id="test_owner",state="unmapped",volume_id="2",volume_name="test-volume2"} 1`)
+	assert.NotContains(t, got, `solidfire_volume_state{account_id="test_owner",state="deleted",volume_id="3",volume_name="test-volume3"} 1`)
+}
@@ -0,0 +1,260 @@
+package solidfire
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheObserver is notified of every call through a CachedClient for a method
+// with a TTL.
+type CacheObserver interface {
+	ObserveCache(method RPC, hit bool)
+}
+
+// CachedClient wraps an Interface and caches responses per method for the
+// configured TTL. Methods without a TTL are not cached.
+type CachedClient struct {
+	Interface
+	ttls     map[RPC]time.Duration
+	observer CacheObserver
+
+	mu      sync.Mutex
+	entries map[RPC]cacheEntry
+}
+
+type cacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+type forceRefreshKey struct{}
+
+// WithForceRefresh returns a context that makes a CachedClient bypass and
+// refresh its cache, e.g. after a lookup found an object the cached response
+// does not know about yet.
+func WithForceRefresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forceRefreshKey{}, true)
+}
+
+func isForceRefresh(ctx context.Context) bool {
+	force, _ := ctx.Value(forceRefreshKey{}).(bool)
+	return force
+}
+
+func NewCachedClient(client Interface, ttls map[RPC]time.Duration, observer CacheObserver) *CachedClient {
+	return &CachedClient{
+		Interface: client,
+		ttls:      ttls,
+		observer:  observer,
+		entries:   make(map[RPC]cacheEntry),
+	}
+}
+
+// CacheableRPCs lists the methods of Interface that CachedClient can cache.
+var CacheableRPCs = []RPC{
+	RPCGetClusterCapacity,
+	RPCGetClusterFullThreshold,
+	RPCGetClusterStats,
+	RPCListAllNodes,
+	RPCListClusterFaults,
+	RPCListDrives,
+	RPCListISCSISessions,
+	RPCListNodeStats,
+	RPCListVolumeQoSHistograms,
+	RPCListVolumes,
+	RPCListVolumeStats,
+	RPCListAccounts,
+	RPCListInitiators,
+	RPCListVolumeAccessGroups,
+	RPCListVirtualVolumeTasks,
+	RPCListAsyncResults,
+	RPCListBulkVolumeJobs,
+	RPCGetClusterInfo,
+	RPCListQoSPolicies,
+	RPCListDeletedVolumes,
+	RPCListSnapshots,
+	RPCListGroupSnapshots,
+	RPCListSchedules,
+	RPCListClusterPairs,
+	RPCListActivePairedVolumes,
+	RPCListSnapMirrorEndpoints,
+	RPCListSnapMirrorRelationships,
+	RPCGetClusterVersionInfo,
+	RPCGetIpmiInfo,
+}
+
+// ParseCacheTTLs converts method name to duration settings, e.g. from the
+// cache.ttls config section, into TTLs. Method names are case-insensitive
+// since config keys are lowercased.
+func ParseCacheTTLs(settings map[string]string) (map[RPC]time.Duration, error) {
+	ttls := make(map[RPC]time.Duration, len(settings))
+	for name, value := range settings {
+		var method RPC
+		for _, rpc := range CacheableRPCs {
+			if strings.EqualFold(string(rpc), name) {
+				method = rpc
+			}
+		}
+		if method == "" {
+			return nil, fmt.Errorf("unknown or uncacheable method %q", name)
+		}
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache TTL for %v: %v", method, err)
+		}
+		ttls[method] = ttl
+	}
+	return ttls, nil
+}
+
+// cached returns the cached response of method, or calls fetch and caches its
+// result. Errors are never cached.
+func cached[R any](ctx context.Context, c *CachedClient, method RPC, fetch func(context.Context) (R, error)) (R, error) {
+	ttl := c.ttls[method]
+	if ttl <= 0 {
+		return fetch(ctx)
+	}
+	if !isForceRefresh(ctx) {
+		c.mu.Lock()
+		entry, ok := c.entries[method]
+		c.mu.Unlock()
+		if ok && time.Now().Before(entry.expires) {
+			c.observe(method, true)
+			return entry.value.(R), nil
+		}
+	}
+	c.observe(method, false)
+	r, err := fetch(ctx)
+	if err != nil {
+		return r, err
+	}
+	c.mu.Lock()
+	c.entries[method] = cacheEntry{value: r, expires: time.Now().Add(ttl)}
+	c.mu.Unlock()
+	return r, nil
+}
+
+func (c *CachedClient) observe(method RPC, hit bool) {
+	if c.observer != nil {
+		c.observer.ObserveCache(method, hit)
+	}
+}
+
+func (c *CachedClient) GetClusterCapacity(ctx context.Context) (GetClusterCapacityResponse, error) {
+	return cached(ctx, c, RPCGetClusterCapacity, c.Interface.GetClusterCapacity)
+}
+
+func (c *CachedClient) GetClusterFullThreshold(ctx context.Context) (GetClusterFullThresholdResponse, error) {
+	return cached(ctx, c, RPCGetClusterFullThreshold, c.Interface.GetClusterFullThreshold)
+}
+
+func (c *CachedClient) GetClusterStats(ctx context.Context) (GetClusterStatsResponse, error) {
+	return cached(ctx, c, RPCGetClusterStats, c.Interface.GetClusterStats)
+}
+
+func (c *CachedClient) ListAllNodes(ctx context.Context) (ListAllNodesResponse, error) {
+	return cached(ctx, c, RPCListAllNodes, c.Interface.ListAllNodes)
+}
+
+func (c *CachedClient) ListClusterFaults(ctx context.Context) (ListClusterFaultsResponse, error) {
+	return cached(ctx, c, RPCListClusterFaults, c.Interface.ListClusterFaults)
+}
+
+func (c *CachedClient) ListDrives(ctx context.Context) (ListDrivesResponse, error) {
+	return cached(ctx, c, RPCListDrives, c.Interface.ListDrives)
+}
+
+func (c *CachedClient) ListISCSISessions(ctx context.Context) (ListISCSISessionsResponse, error) {
+	return cached(ctx, c, RPCListISCSISessions, c.Interface.ListISCSISessions)
+}
+
+func (c *CachedClient) ListNodeStats(ctx context.Context) (ListNodeStatsResponse, error) {
+	return cached(ctx, c, RPCListNodeStats, c.Interface.ListNodeStats)
+}
+
+func (c *CachedClient) ListVolumeQoSHistograms(ctx context.Context) (ListVolumeQoSHistogramsResponse, error) {
+	return cached(ctx, c, RPCListVolumeQoSHistograms, c.Interface.ListVolumeQoSHistograms)
+}
+
+func (c *CachedClient) ListVolumes(ctx context.Context) (ListVolumesResponse, error) {
+	return cached(ctx, c, RPCListVolumes, c.Interface.ListVolumes)
+}
+
+func (c *CachedClient) ListVolumeStats(ctx context.Context) (ListVolumeStatsResponse, error) {
+	return cached(ctx, c, RPCListVolumeStats, c.Interface.ListVolumeStats)
+}
+
+func (c *CachedClient) ListAccounts(ctx context.Context) (ListAccountsResponse, error) {
+	return cached(ctx, c, RPCListAccounts, c.Interface.ListAccounts)
+}
+
+func (c *CachedClient) ListInitiators(ctx context.Context) (ListInitiatorsResponse, error) {
+	return cached(ctx, c, RPCListInitiators, c.Interface.ListInitiators)
+}
+
+func (c *CachedClient) ListVolumeAccessGroups(ctx context.Context) (ListVolumeAccessGroupsResponse, error) {
+	return cached(ctx, c, RPCListVolumeAccessGroups, c.Interface.ListVolumeAccessGroups)
+}
+
+func (c *CachedClient) ListVirtualVolumeTasks(ctx context.Context) (ListVirtualVolumeTasksResponse, error) {
+	return cached(ctx, c, RPCListVirtualVolumeTasks, c.Interface.ListVirtualVolumeTasks)
+}
+
+func (c *CachedClient) ListAsyncResults(ctx context.Context) (ListAsyncResultsResponse, error) {
+	return cached(ctx, c, RPCListAsyncResults, c.Interface.ListAsyncResults)
+}
+
+func (c *CachedClient) ListBulkVolumeJobs(ctx context.Context) (ListBulkVolumeJobsResponse, error) {
+	return cached(ctx, c, RPCListBulkVolumeJobs, c.Interface.ListBulkVolumeJobs)
+}
+
+func (c *CachedClient) GetClusterInfo(ctx context.Context) (GetClusterInfoResponse, error) {
+	return cached(ctx, c, RPCGetClusterInfo, c.Interface.GetClusterInfo)
+}
+
+func (c *CachedClient) ListQoSPolicies(ctx context.Context) (ListQoSPoliciesResponse, error) {
+	return cached(ctx, c, RPCListQoSPolicies, c.Interface.ListQoSPolicies)
+}
+
+func (c *CachedClient) ListDeletedVolumes(ctx context.Context) (ListDeletedVolumesResponse, error) {
+	return cached(ctx, c, RPCListDeletedVolumes, c.Interface.ListDeletedVolumes)
+}
+
+func (c *CachedClient) ListSnapshots(ctx context.Context) (ListSnapshotsResponse, error) {
+	return cached(ctx, c, RPCListSnapshots, c.Interface.ListSnapshots)
+}
+
+func (c *CachedClient) ListGroupSnapshots(ctx context.Context) (ListGroupSnapshotsResponse, error) {
+	return cached(ctx, c, RPCListGroupSnapshots, c.Interface.ListGroupSnapshots)
+}
+
+func (c *CachedClient) ListSchedules(ctx context.Context) (ListSchedulesResponse, error) {
+	return cached(ctx, c, RPCListSchedules, c.Interface.ListSchedules)
+}
+
+func (c *CachedClient) ListClusterPairs(ctx context.Context) (ListClusterPairsResponse, error) {
+	return cached(ctx, c, RPCListClusterPairs, c.Interface.ListClusterPairs)
+}
+
+func (c *CachedClient) ListActivePairedVolumes(ctx context.Context) (ListActivePairedVolumesResponse, error) {
+	return cached(ctx, c, RPCListActivePairedVolumes, c.Interface.ListActivePairedVolumes)
+}
+
+func (c *CachedClient) ListSnapMirrorEndpoints(ctx context.Context) (ListSnapMirrorEndpointsResponse, error) {
+	return cached(ctx, c, RPCListSnapMirrorEndpoints, c.Interface.ListSnapMirrorEndpoints)
+}
+
+func (c *CachedClient) ListSnapMirrorRelationships(ctx context.Context) (ListSnapMirrorRelationshipsResponse, error) {
+	return cached(ctx, c, RPCListSnapMirrorRelationships, c.Interface.ListSnapMirrorRelationships)
+}
+
+func (c *CachedClient) GetClusterVersionInfo(ctx context.Context) (GetClusterVersionInfoResponse, error) {
+	return cached(ctx, c, RPCGetClusterVersionInfo, c.Interface.GetClusterVersionInfo)
+}
+
+func (c *CachedClient) GetIpmiInfo(ctx context.Context) (GetIpmiInfoResponse, error) {
+	return cached(ctx, c, RPCGetIpmiInfo, c.Interface.GetIpmiInfo)
+}
@@ -0,0 +1,114 @@
+package solidfire
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	log "github.com/amoghe/distillog"
+)
+
+// Caller sends a single JSON-RPC request and decodes its result into result,
+// which must be a pointer to a response struct.
+type Caller interface {
+	CallRPC(ctx context.Context, method RPC, params interface{}, result interface{}) error
+}
+
+// Call invokes method on c with params and decodes the response into R. It can
+// be used for API methods the Client does not wrap:
+//
+//	r, err := solidfire.Call[solidfire.GetClusterInfoParams, solidfire.GetClusterInfoResponse](
+//		ctx, client, solidfire.RPCGetClusterInfo, solidfire.GetClusterInfoParams{})
+func Call[P, R any](ctx context.Context, c Caller, method RPC, params P) (R, error) {
+	var r R
+	err := c.CallRPC(ctx, method, params, &r)
+	return r, err
+}
+
+// Observer is notified after every RPC attempt, including retries.
+type Observer interface {
+	ObserveRPC(method RPC, duration time.Duration, err error)
+}
+
+// rpcEnvelope is the part of a JSON-RPC response shared by every method.
+type rpcEnvelope struct {
+	ID    json.RawMessage `json:"id"`
+	Error *APIError       `json:"error"`
+}
+
+// CallRPC implements Caller. Every attempt is sent with a new request id, and
+// the id echoed back by the cluster must match it. Transient failures are
+// retried according to c.Retry.
+func (c *Client) CallRPC(ctx context.Context, method RPC, params interface{}, result interface{}) error {
+	endpoint, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return err
+	}
+	return c.call(ctx, endpoint, method, params, result)
+}
+
+func (c *Client) call(ctx context.Context, endpoint string, method RPC, params interface{}, result interface{}) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		err = c.callOnce(ctx, endpoint, method, params, result)
+		if c.Observer != nil {
+			c.Observer.ObserveRPC(method, time.Since(start), err)
+		}
+		if err == nil || attempt >= c.Retry.MaxAttempts || ctx.Err() != nil || !c.Retry.retryable(err) {
+			return err
+		}
+		if !c.Retry.wait(ctx, attempt) {
+			return err
+		}
+		log.Debugf("retrying %v (attempt %d of %d) after error: %v", method, attempt+1, c.Retry.MaxAttempts, err)
+	}
+}
+
+func (c *Client) callOnce(ctx context.Context, endpoint string, method RPC, params interface{}, result interface{}) error {
+	id := int(c.requestID.Add(1))
+	payload := &RPCBody{
+		Method: method,
+		Params: params,
+		ID:     id,
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("Error encoding %v request: %v", method, err)
+	}
+	bodyBytes, err := c.doRpcCall(ctx, endpoint, method, payloadBytes)
+	if err != nil {
+		return err
+	}
+	if err = checkEnvelope(method, id, bodyBytes); err != nil {
+		return err
+	}
+	if err = json.Unmarshal(bodyBytes, result); err != nil {
+		return fmt.Errorf("Error decoding %v response: %v", method, err)
+	}
+	return nil
+}
+
+// checkEnvelope returns an *APIError if body carries a JSON-RPC error object,
+// or an error if the response id does not match the request id. Responses
+// without an id are accepted.
+func checkEnvelope(method RPC, id int, body []byte) error {
+	var e rpcEnvelope
+	if err := json.Unmarshal(body, &e); err != nil {
+		return fmt.Errorf("Error decoding %v response: %v", method, err)
+	}
+	if e.Error != nil {
+		e.Error.Method = method
+		return e.Error
+	}
+	if len(e.ID) == 0 || bytes.Equal(e.ID, []byte("null")) {
+		return nil
+	}
+	if string(bytes.Trim(e.ID, `"`)) != strconv.Itoa(id) {
+		return fmt.Errorf("Received mismatched response id for %v: expected %v, got %s", method, id, e.ID)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package solidfire
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// Error names returned by the SolidFire API in the JSON-RPC error object.
+const (
+	ErrNamePermissionDenied  = "xPermissionDenied"
+	ErrNameNotAuthorized     = "xNotAuthorized"
+	ErrNameUnknownAPIMethod  = "xUnknownAPIMethod"
+	ErrNameUnknownAPIVersion = "xUnknownAPIVersion"
+)
+
+// APIError is an API-level failure reported by SolidFire. These come back
+// with HTTP 200 and an "error" object in place of "result".
+type APIError struct {
+	Method  RPC    `json:"-"`
+	Name    string `json:"name"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%v returned %v (code %v): %v", e.Method, e.Name, e.Code, e.Message)
+}
+
+// IsPermissionDenied reports whether the API user lacks the access level
+// required by the method, e.g. the Element 11.3 QoS histogram admin bug.
+func (e *APIError) IsPermissionDenied() bool {
+	return e.Name == ErrNamePermissionDenied || e.Name == ErrNameNotAuthorized
+}
+
+// IsPermissionDenied reports whether err wraps an APIError caused by
+// insufficient privileges.
+func IsPermissionDenied(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.IsPermissionDenied()
+}
+
+// ErrorKind classifies err for use as a metric label: the API error name,
+// http_<status code>, timeout, canceled, transport or invalid_response.
+func ErrorKind(err error) string {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Name
+	}
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return fmt.Sprintf("http_%d", statusErr.StatusCode)
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timeout"
+	}
+	if errors.Is(err, context.Canceled) {
+		return "canceled"
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		if urlErr.Timeout() {
+			return "timeout"
+		}
+		return "transport"
+	}
+	return "invalid_response"
+}
@@ -0,0 +1,110 @@
+package solidfire
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/url"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Error names returned by the SolidFire API while the cluster master service
+// is failing over. Calls failing with these are safe to retry.
+const (
+	ErrNameServiceUnavailable = "xServiceUnavailable"
+	ErrNameDBConnectionLoss   = "xDBConnectionLoss"
+)
+
+// RetryPolicy controls how a Client retries transient failures. Transport
+// errors, API errors named in RetryableErrors and HTTP status codes listed in
+// RetryableStatusCodes are retried with exponential backoff and jitter.
+// A MaxAttempts of 0 or 1 disables retries.
+type RetryPolicy struct {
+	MaxAttempts          int
+	BaseBackoff          time.Duration
+	MaxBackoff           time.Duration
+	RetryableErrors      []string
+	RetryableStatusCodes []int
+}
+
+// HTTPStatusError is returned when the API answers with a non-200 status.
+type HTTPStatusError struct {
+	Method     RPC
+	StatusCode int
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("Received invalid status code from RPC call %v: %v", e.Method, e.StatusCode)
+}
+
+// RetryPolicyFromConfig builds a RetryPolicy from the client.retry.* settings.
+func RetryPolicyFromConfig() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:          viper.GetInt(RetryMaxAttempts),
+		BaseBackoff:          viper.GetDuration(RetryBaseBackoff),
+		MaxBackoff:           viper.GetDuration(RetryMaxBackoff),
+		RetryableErrors:      viper.GetStringSlice(RetryErrorNames),
+		RetryableStatusCodes: viper.GetIntSlice(RetryStatusCodes),
+	}
+}
+
+// retryable reports whether err is a transient failure worth another attempt.
+func (p RetryPolicy) retryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		for _, name := range p.RetryableErrors {
+			if apiErr.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		for _, code := range p.RetryableStatusCodes {
+			if statusErr.StatusCode == code {
+				return true
+			}
+		}
+		return false
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// backoff returns the delay before retry number attempt (starting at 1): a
+// random duration between half and all of BaseBackoff*2^(attempt-1), capped
+// at MaxBackoff.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	if p.BaseBackoff <= 0 {
+		return 0
+	}
+	d := p.BaseBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// wait sleeps for the backoff before retry number attempt. It returns false
+// without sleeping when the delay would run past the context deadline.
+func (p RetryPolicy) wait(ctx context.Context, attempt int) bool {
+	d := p.backoff(attempt)
+	if deadline, ok := ctx.Deadline(); ok && time.Now().Add(d).After(deadline) {
+		return false
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
@@ -4,11 +4,11 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	log "github.com/amoghe/distillog"
@@ -18,33 +18,58 @@ import (
 type RPC string
 
 const (
-	RPCGetClusterCapacity      RPC = "GetClusterCapacity"
-	RPCGetClusterFullThreshold RPC = "GetClusterFullThreshold"
-	RPCGetClusterStats         RPC = "GetClusterStats"
-	RPCListAllNodes            RPC = "ListAllNodes"
-	RPCListClusterFaults       RPC = "ListClusterFaults"
-	RPCListDrives              RPC = "ListDrives"
-	RPCListISCSISessions       RPC = "ListISCSISessions"
-	RPCListNodeStats           RPC = "ListNodeStats"
-	RPCListVolumeQoSHistograms RPC = "ListVolumeQoSHistograms"
-	RPCListVolumes             RPC = "ListVolumes"
-	RPCListVolumeStats         RPC = "ListVolumeStats"
-	RPCListAccounts            RPC = "ListAccounts"
-	RPCListInitiators          RPC = "ListInitiators"
-	RPCListVolumeAccessGroups  RPC = "ListVolumeAccessGroups"
-	RPCListVirtualVolumeTasks  RPC = "ListVirtualVolumeTasks"
-	RPCListBulkVolumeJobs      RPC = "ListBulkVolumeJobs"
-	RPCListAsyncResults        RPC = "ListAsyncResults"
+	RPCGetClusterCapacity          RPC = "GetClusterCapacity"
+	RPCGetClusterFullThreshold     RPC = "GetClusterFullThreshold"
+	RPCGetClusterStats             RPC = "GetClusterStats"
+	RPCListAllNodes                RPC = "ListAllNodes"
+	RPCListClusterFaults           RPC = "ListClusterFaults"
+	RPCListDrives                  RPC = "ListDrives"
+	RPCListISCSISessions           RPC = "ListISCSISessions"
+	RPCListNodeStats               RPC = "ListNodeStats"
+	RPCListVolumeQoSHistograms     RPC = "ListVolumeQoSHistograms"
+	RPCListVolumes                 RPC = "ListVolumes"
+	RPCListVolumeStats             RPC = "ListVolumeStats"
+	RPCListAccounts                RPC = "ListAccounts"
+	RPCListInitiators              RPC = "ListInitiators"
+	RPCListVolumeAccessGroups      RPC = "ListVolumeAccessGroups"
+	RPCListVirtualVolumeTasks      RPC = "ListVirtualVolumeTasks"
+	RPCListBulkVolumeJobs          RPC = "ListBulkVolumeJobs"
+	RPCListAsyncResults            RPC = "ListAsyncResults"
+	RPCGetClusterInfo              RPC = "GetClusterInfo"
+	RPCGetAPI                      RPC = "GetAPI"
+	RPCGetClusterVersionInfo       RPC = "GetClusterVersionInfo"
+	RPCListQoSPolicies             RPC = "ListQoSPolicies"
+	RPCListDeletedVolumes          RPC = "ListDeletedVolumes"
+	RPCListSnapshots               RPC = "ListSnapshots"
+	RPCListGroupSnapshots          RPC = "ListGroupSnapshots"
+	RPCListSchedules               RPC = "ListSchedules"
+	RPCListClusterPairs            RPC = "ListClusterPairs"
+	RPCListActivePairedVolumes     RPC = "ListActivePairedVolumes"
+	RPCListSnapMirrorEndpoints     RPC = "ListSnapMirrorEndpoints"
+	RPCListSnapMirrorRelationships RPC = "ListSnapMirrorRelationships"
+	RPCGetClusterMasterNodeID      RPC = "GetClusterMasterNodeID"
+	RPCGetIpmiInfo                 RPC = "GetIpmiInfo"
+	RPCGetNodeHardwareInfo         RPC = "GetNodeHardwareInfo"
 )
 
 func NewSolidfireClient() (*Client, error) {
+	return NewSolidfireClientWithOpts(&ClientOpts{
+		Endpoint: viper.GetString(Endpoint),
+		Username: viper.GetString(Username),
+		Password: viper.GetString(Password),
+		Insecure: viper.GetBool(InsecureSSL),
+		Timeout:  time.Duration(viper.GetInt64(HTTPClientTimeout)) * time.Second,
+		Retry:    RetryPolicyFromConfig(),
+	})
+}
+
+func NewSolidfireClientWithOpts(opts *ClientOpts) (*Client, error) {
 	log.Infof("initializing new solidfire client")
 
-	insecure := viper.GetBool(InsecureSSL)
-	if insecure {
+	if opts.Insecure {
 		log.Warningln("TLS certificate verification is currently disabled - This is not recommended.")
 	}
-	rpcServer := viper.GetString(Endpoint)
+	rpcServer := opts.Endpoint
 	_, err := url.Parse(rpcServer)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing RPC Server url: %s", err.Error())
@@ -52,34 +77,61 @@ func NewSolidfireClient() (*Client, error) {
 	log.Infoln("RPC Server:", rpcServer)
 
 	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: insecure},
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: opts.Insecure},
 	}
 	return &Client{
 		HttpClient: &http.Client{
 			Transport: tr,
-			Timeout:   time.Duration(viper.GetInt64(HTTPClientTimeout)) * time.Second,
+			Timeout:   opts.Timeout,
 		},
-		Username:    viper.GetString(Username),
-		Password:    viper.GetString(Password),
+		Username:    opts.Username,
+		Password:    opts.Password,
 		RPCEndpoint: rpcServer,
+		Retry:       opts.Retry,
+		Observer:    opts.Observer,
 	}, nil
 }
 
-func (c *Client) doRpcCall(ctx context.Context, body []byte) ([]byte, error) {
-	req, err := http.NewRequestWithContext(ctx, "POST", c.RPCEndpoint, bytes.NewReader(body))
+// EndpointForTarget builds a JSON-RPC endpoint from a probe target. Targets
+// may be a bare MVIP (e.g. 10.0.0.1), a host with a scheme, or a full
+// endpoint URL; a missing scheme defaults to https and a missing path to
+// /json-rpc/<apiVersion>. With an empty apiVersion the path is left empty and
+// the client negotiates the version.
+func EndpointForTarget(target string, apiVersion string) (string, error) {
+	if target == "" {
+		return "", fmt.Errorf("target is empty")
+	}
+	if !strings.Contains(target, "://") {
+		target = "https://" + target
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return "", fmt.Errorf("error parsing target %q: %s", target, err.Error())
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("target %q has no host", target)
+	}
+	if (u.Path == "" || u.Path == "/") && apiVersion != "" {
+		u.Path = "/json-rpc/" + apiVersion
+	}
+	return u.String(), nil
+}
+
+func (c *Client) doRpcCall(ctx context.Context, endpoint string, method RPC, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
 	if err != nil {
-		return nil, fmt.Errorf("Error building RPC request to %v: %v", c.RPCEndpoint, err)
+		return nil, fmt.Errorf("Error building RPC request to %v: %v", endpoint, err)
 	}
 	req.SetBasicAuth(c.Username, c.Password)
 	req.Header.Set("Content-Type", "application/json")
 	resp, err := c.HttpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("Error making RPC call %v: %v", string(body), err)
+		return nil, fmt.Errorf("Error making RPC call %v: %w", string(body), err)
 	}
 
 	defer resp.Body.Close()
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Received invalid status code from RPC call: %v", resp.StatusCode)
+		return nil, &HTTPStatusError{Method: method, StatusCode: resp.StatusCode}
 	}
 
 	body, err = ioutil.ReadAll(resp.Body)
@@ -91,374 +143,139 @@ func (c *Client) doRpcCall(ctx context.Context, body []byte) ([]byte, error) {
 }
 
 func (s *Client) ListVolumes(ctx context.Context) (ListVolumesResponse, error) {
-	payload := &RPCBody{
-		Method: RPCListVolumes,
-		Params: ListVolumesRPCParams{
-			IncludeVirtualVolumes: true,
-		},
-		ID: 1,
-	}
-	payloadBytes, err := json.Marshal(&payload)
-	r := ListVolumesResponse{}
-	bodyBytes, err := s.doRpcCall(ctx, payloadBytes)
-	if err != nil {
-		return r, err
-	}
-	err = json.Unmarshal(bodyBytes, &r)
-	if err != nil {
-		return r, err
-	}
-	return r, nil
+	return Call[ListVolumesRPCParams, ListVolumesResponse](ctx, s, RPCListVolumes, ListVolumesRPCParams{
+		IncludeVirtualVolumes: true,
+	})
 }
 
 func (s *Client) ListVolumeStats(ctx context.Context) (ListVolumeStatsResponse, error) {
-	payload := &RPCBody{
-		Method: RPCListVolumeStats,
-		Params: ListVolumeStatsRPCParams{
-			VolumeIDs:             []int{}, // blank gives us all of them
-			IncludeVirtualVolumes: true,
-		},
-		ID: 1,
-	}
-	payloadBytes, err := json.Marshal(&payload)
-	r := ListVolumeStatsResponse{}
-	bodyBytes, err := s.doRpcCall(ctx, payloadBytes)
-	if err != nil {
-		return r, err
-	}
-	err = json.Unmarshal(bodyBytes, &r)
-
-	if err != nil {
-		return r, err
-	}
-	return r, nil
+	return Call[ListVolumeStatsRPCParams, ListVolumeStatsResponse](ctx, s, RPCListVolumeStats, ListVolumeStatsRPCParams{
+		VolumeIDs:             []int{}, // blank gives us all of them
+		IncludeVirtualVolumes: true,
+	})
 }
 
 func (s *Client) GetClusterCapacity(ctx context.Context) (GetClusterCapacityResponse, error) {
-	payload := &RPCBody{
-		Method: RPCGetClusterCapacity,
-		Params: GetClusterCapacityRPCParams{},
-		ID:     1,
-	}
-	payloadBytes, err := json.Marshal(&payload)
-	r := GetClusterCapacityResponse{}
-	bodyBytes, err := s.doRpcCall(ctx, payloadBytes)
-	if err != nil {
-		return r, err
-	}
-	err = json.Unmarshal(bodyBytes, &r)
-	if err != nil {
-		return r, err
-	}
-	return r, nil
+	return Call[GetClusterCapacityRPCParams, GetClusterCapacityResponse](ctx, s, RPCGetClusterCapacity, GetClusterCapacityRPCParams{})
 }
 
 func (s *Client) ListClusterFaults(ctx context.Context) (ListClusterFaultsResponse, error) {
-	payload := &RPCBody{
-		Method: RPCListClusterFaults,
-		Params: ListClusterFaultsRPCParams{
-			FaultTypes:    "current",
-			BestPractices: true,
-		},
-		ID: 1,
-	}
-
-	payloadBytes, err := json.Marshal(&payload)
-	r := ListClusterFaultsResponse{}
-	bodyBytes, err := s.doRpcCall(ctx, payloadBytes)
-	if err != nil {
-		return r, err
-	}
-	err = json.Unmarshal(bodyBytes, &r)
-
-	if err != nil {
-		return r, err
-	}
-	return r, nil
+	return Call[ListClusterFaultsRPCParams, ListClusterFaultsResponse](ctx, s, RPCListClusterFaults, ListClusterFaultsRPCParams{
+		FaultTypes:    "current",
+		BestPractices: true,
+	})
 }
 
 func (s *Client) ListNodeStats(ctx context.Context) (ListNodeStatsResponse, error) {
-	payload := &RPCBody{
-		Method: RPCListNodeStats,
-		Params: ListNodeStatsRPCParams{},
-		ID:     1,
-	}
-
-	payloadBytes, err := json.Marshal(&payload)
-	r := ListNodeStatsResponse{}
-	bodyBytes, err := s.doRpcCall(ctx, payloadBytes)
-	if err != nil {
-		return r, err
-	}
-	err = json.Unmarshal(bodyBytes, &r)
-	if err != nil {
-		return r, err
-	}
-	return r, nil
+	return Call[ListNodeStatsRPCParams, ListNodeStatsResponse](ctx, s, RPCListNodeStats, ListNodeStatsRPCParams{})
 }
 
 func (s *Client) ListVolumeQoSHistograms(ctx context.Context) (ListVolumeQoSHistogramsResponse, error) {
-	payload := &RPCBody{
-		Method: RPCListVolumeQoSHistograms,
-		Params: ListVolumeQoSHistogramsRPCParams{
-			VolumeIDs: []int{}, // blank gives us all of them
-		},
-		ID: 1,
-	}
-
-	payloadBytes, err := json.Marshal(&payload)
-	r := ListVolumeQoSHistogramsResponse{}
-	bodyBytes, err := s.doRpcCall(ctx, payloadBytes)
-
-	if err != nil {
-		return r, err
-	}
-	err = json.Unmarshal(bodyBytes, &r)
-
-	if err != nil {
-		return r, err
-	}
-	return r, nil
+	return Call[ListVolumeQoSHistogramsRPCParams, ListVolumeQoSHistogramsResponse](ctx, s, RPCListVolumeQoSHistograms, ListVolumeQoSHistogramsRPCParams{
+		VolumeIDs: []int{}, // blank gives us all of them
+	})
 }
 
 func (s *Client) ListAllNodes(ctx context.Context) (ListAllNodesResponse, error) {
-	payload := &RPCBody{
-		Method: RPCListAllNodes,
-		Params: ListAllNodesRPCParams{},
-		ID:     1,
-	}
-
-	payloadBytes, err := json.Marshal(&payload)
-	r := ListAllNodesResponse{}
-	bodyBytes, err := s.doRpcCall(ctx, payloadBytes)
-
-	if err != nil {
-		return r, err
-	}
-	err = json.Unmarshal(bodyBytes, &r)
-
-	if err != nil {
-		return r, err
-	}
-	return r, nil
+	return Call[ListAllNodesRPCParams, ListAllNodesResponse](ctx, s, RPCListAllNodes, ListAllNodesRPCParams{})
 }
 
 func (s *Client) GetClusterStats(ctx context.Context) (GetClusterStatsResponse, error) {
-	payload := &RPCBody{
-		Method: RPCGetClusterStats,
-		Params: GetClusterStatsRPCParams{},
-		ID:     1,
-	}
-
-	payloadBytes, err := json.Marshal(&payload)
-	r := GetClusterStatsResponse{}
-	bodyBytes, err := s.doRpcCall(ctx, payloadBytes)
-
-	if err != nil {
-		return r, err
-	}
-	err = json.Unmarshal(bodyBytes, &r)
-
-	if err != nil {
-		return r, err
-	}
-	return r, nil
+	return Call[GetClusterStatsRPCParams, GetClusterStatsResponse](ctx, s, RPCGetClusterStats, GetClusterStatsRPCParams{})
 }
 
 func (s *Client) GetClusterFullThreshold(ctx context.Context) (GetClusterFullThresholdResponse, error) {
-	payload := &RPCBody{
-		Method: RPCGetClusterFullThreshold,
-		Params: GetClusterFullThresholdParams{},
-		ID:     1,
-	}
-
-	payloadBytes, err := json.Marshal(&payload)
-	r := GetClusterFullThresholdResponse{}
-	bodyBytes, err := s.doRpcCall(ctx, payloadBytes)
-
-	if err != nil {
-		return r, err
-	}
-	err = json.Unmarshal(bodyBytes, &r)
-
-	if err != nil {
-		return r, err
-	}
-	return r, nil
+	return Call[GetClusterFullThresholdParams, GetClusterFullThresholdResponse](ctx, s, RPCGetClusterFullThreshold, GetClusterFullThresholdParams{})
 }
 
 func (s *Client) ListDrives(ctx context.Context) (ListDrivesResponse, error) {
-	payload := &RPCBody{
-		Method: RPCListDrives,
-		Params: ListDrivesParams{},
-		ID:     1,
-	}
-
-	payloadBytes, err := json.Marshal(&payload)
-	r := ListDrivesResponse{}
-	bodyBytes, err := s.doRpcCall(ctx, payloadBytes)
-
-	if err != nil {
-		return r, err
-	}
-	err = json.Unmarshal(bodyBytes, &r)
-
-	if err != nil {
-		return r, err
-	}
-	return r, nil
+	return Call[ListDrivesParams, ListDrivesResponse](ctx, s, RPCListDrives, ListDrivesParams{})
 }
 
 func (s *Client) ListISCSISessions(ctx context.Context) (ListISCSISessionsResponse, error) {
-	payload := &RPCBody{
-		Method: RPCListISCSISessions,
-		Params: ListISCSISessionsParams{},
-		ID:     1,
-	}
-
-	payloadBytes, err := json.Marshal(&payload)
-	r := ListISCSISessionsResponse{}
-	bodyBytes, err := s.doRpcCall(ctx, payloadBytes)
-
-	if err != nil {
-		return r, err
-	}
-	err = json.Unmarshal(bodyBytes, &r)
-
-	if err != nil {
-		return r, err
-	}
-	return r, nil
+	return Call[ListISCSISessionsParams, ListISCSISessionsResponse](ctx, s, RPCListISCSISessions, ListISCSISessionsParams{})
 }
 
 func (s *Client) ListAccounts(ctx context.Context) (ListAccountsResponse, error) {
-	payload := &RPCBody{
-		Method: RPCListAccounts,
-		Params: ListAccountsParams{},
-		ID:     1,
-	}
-
-	payloadBytes, err := json.Marshal(&payload)
-	r := ListAccountsResponse{}
-	bodyBytes, err := s.doRpcCall(ctx, payloadBytes)
-
-	if err != nil {
-		return r, err
-	}
-	err = json.Unmarshal(bodyBytes, &r)
-
-	if err != nil {
-		return r, err
-	}
-	return r, nil
+	return Call[ListAccountsParams, ListAccountsResponse](ctx, s, RPCListAccounts, ListAccountsParams{})
 }
 
 func (s *Client) ListInitiators(ctx context.Context) (ListInitiatorsResponse, error) {
-	payload := &RPCBody{
-		Method: RPCListInitiators,
-		Params: ListInitiatorsParams{},
-		ID:     1,
-	}
-
-	payloadBytes, err := json.Marshal(&payload)
-	r := ListInitiatorsResponse{}
-	bodyBytes, err := s.doRpcCall(ctx, payloadBytes)
+	return Call[ListInitiatorsParams, ListInitiatorsResponse](ctx, s, RPCListInitiators, ListInitiatorsParams{})
+}
 
-	if err != nil {
-		return r, err
-	}
-	err = json.Unmarshal(bodyBytes, &r)
+func (s *Client) ListVolumeAccessGroups(ctx context.Context) (ListVolumeAccessGroupsResponse, error) {
+	return Call[ListVolumeAccessGroupsParams, ListVolumeAccessGroupsResponse](ctx, s, RPCListVolumeAccessGroups, ListVolumeAccessGroupsParams{})
+}
 
-	if err != nil {
-		return r, err
-	}
-	return r, nil
+func (s *Client) ListVirtualVolumeTasks(ctx context.Context) (ListVirtualVolumeTasksResponse, error) {
+	return Call[ListVirtualVolumeTasksParams, ListVirtualVolumeTasksResponse](ctx, s, RPCListVirtualVolumeTasks, ListVirtualVolumeTasksParams{})
 }
 
-func (s *Client) ListVolumeAccessGroups(ctx context.Context) (ListVolumeAccessGroupsResponse, error) {
-	payload := &RPCBody{
-		Method: RPCListVolumeAccessGroups,
-		Params: ListVolumeAccessGroupsParams{},
-		ID:     1,
-	}
+func (s *Client) ListAsyncResults(ctx context.Context) (ListAsyncResultsResponse, error) {
+	return Call[ListAsyncResultsParams, ListAsyncResultsResponse](ctx, s, RPCListAsyncResults, ListAsyncResultsParams{})
+}
 
-	payloadBytes, err := json.Marshal(&payload)
-	r := ListVolumeAccessGroupsResponse{}
-	bodyBytes, err := s.doRpcCall(ctx, payloadBytes)
+func (s *Client) ListBulkVolumeJobs(ctx context.Context) (ListBulkVolumeJobsResponse, error) {
+	return Call[ListBulkVolumeJobsParams, ListBulkVolumeJobsResponse](ctx, s, RPCListBulkVolumeJobs, ListBulkVolumeJobsParams{})
+}
 
-	if err != nil {
-		return r, err
-	}
-	err = json.Unmarshal(bodyBytes, &r)
+func (s *Client) GetClusterInfo(ctx context.Context) (GetClusterInfoResponse, error) {
+	return Call[GetClusterInfoParams, GetClusterInfoResponse](ctx, s, RPCGetClusterInfo, GetClusterInfoParams{})
+}
 
-	if err != nil {
-		return r, err
-	}
-	return r, nil
+func (s *Client) ListQoSPolicies(ctx context.Context) (ListQoSPoliciesResponse, error) {
+	return Call[ListQoSPoliciesParams, ListQoSPoliciesResponse](ctx, s, RPCListQoSPolicies, ListQoSPoliciesParams{})
 }
 
-func (s *Client) ListVirtualVolumeTasks(ctx context.Context) (ListVirtualVolumeTasksResponse, error) {
-	payload := &RPCBody{
-		Method: RPCListVirtualVolumeTasks,
-		Params: ListVirtualVolumeTasksParams{},
-		ID:     1,
-	}
+func (s *Client) ListDeletedVolumes(ctx context.Context) (ListDeletedVolumesResponse, error) {
+	return Call[ListDeletedVolumesParams, ListDeletedVolumesResponse](ctx, s, RPCListDeletedVolumes, ListDeletedVolumesParams{})
+}
 
-	payloadBytes, err := json.Marshal(&payload)
-	r := ListVirtualVolumeTasksResponse{}
-	bodyBytes, err := s.doRpcCall(ctx, payloadBytes)
+func (s *Client) ListSnapshots(ctx context.Context) (ListSnapshotsResponse, error) {
+	return Call[ListSnapshotsParams, ListSnapshotsResponse](ctx, s, RPCListSnapshots, ListSnapshotsParams{})
+}
 
-	if err != nil {
-		return r, err
-	}
-	err = json.Unmarshal(bodyBytes, &r)
+func (s *Client) ListGroupSnapshots(ctx context.Context) (ListGroupSnapshotsResponse, error) {
+	return Call[ListGroupSnapshotsParams, ListGroupSnapshotsResponse](ctx, s, RPCListGroupSnapshots, ListGroupSnapshotsParams{})
+}
 
-	if err != nil {
-		return r, err
-	}
-	return r, nil
+func (s *Client) ListSchedules(ctx context.Context) (ListSchedulesResponse, error) {
+	return Call[ListSchedulesParams, ListSchedulesResponse](ctx, s, RPCListSchedules, ListSchedulesParams{})
 }
 
-func (s *Client) ListAsyncResults(ctx context.Context) (ListAsyncResultsResponse, error) {
-	payload := &RPCBody{
-		Method: RPCListAsyncResults,
-		Params: ListAsyncResultsParams{},
-		ID:     1,
-	}
+func (s *Client) ListClusterPairs(ctx context.Context) (ListClusterPairsResponse, error) {
+	return Call[ListClusterPairsParams, ListClusterPairsResponse](ctx, s, RPCListClusterPairs, ListClusterPairsParams{})
+}
 
-	payloadBytes, err := json.Marshal(&payload)
-	r := ListAsyncResultsResponse{}
-	bodyBytes, err := s.doRpcCall(ctx, payloadBytes)
+func (s *Client) ListActivePairedVolumes(ctx context.Context) (ListActivePairedVolumesResponse, error) {
+	return Call[ListActivePairedVolumesParams, ListActivePairedVolumesResponse](ctx, s, RPCListActivePairedVolumes, ListActivePairedVolumesParams{})
+}
 
-	if err != nil {
-		return r, err
-	}
-	err = json.Unmarshal(bodyBytes, &r)
+func (s *Client) ListSnapMirrorEndpoints(ctx context.Context) (ListSnapMirrorEndpointsResponse, error) {
+	return Call[ListSnapMirrorEndpointsParams, ListSnapMirrorEndpointsResponse](ctx, s, RPCListSnapMirrorEndpoints, ListSnapMirrorEndpointsParams{})
+}
 
-	if err != nil {
-		return r, err
-	}
-	return r, nil
+func (s *Client) ListSnapMirrorRelationships(ctx context.Context) (ListSnapMirrorRelationshipsResponse, error) {
+	return Call[ListSnapMirrorRelationshipsParams, ListSnapMirrorRelationshipsResponse](ctx, s, RPCListSnapMirrorRelationships, ListSnapMirrorRelationshipsParams{})
 }
 
-func (s *Client) ListBulkVolumeJobs(ctx context.Context) (ListBulkVolumeJobsResponse, error) {
-	payload := &RPCBody{
-		Method: RPCListBulkVolumeJobs,
-		Params: ListBulkVolumeJobsParams{},
-		ID:     1,
-	}
+func (s *Client) GetClusterMasterNodeID(ctx context.Context) (GetClusterMasterNodeIDResponse, error) {
+	return Call[GetClusterMasterNodeIDParams, GetClusterMasterNodeIDResponse](ctx, s, RPCGetClusterMasterNodeID, GetClusterMasterNodeIDParams{})
+}
 
-	payloadBytes, err := json.Marshal(&payload)
-	r := ListBulkVolumeJobsResponse{}
-	bodyBytes, err := s.doRpcCall(ctx, payloadBytes)
+func (s *Client) GetIpmiInfo(ctx context.Context) (GetIpmiInfoResponse, error) {
+	return Call[GetIpmiInfoParams, GetIpmiInfoResponse](ctx, s, RPCGetIpmiInfo, GetIpmiInfoParams{})
+}
 
-	if err != nil {
-		return r, err
-	}
-	err = json.Unmarshal(bodyBytes, &r)
+// GetNodeHardwareInfo is the cluster API counterpart of the per-node
+// GetHardwareInfo method, returning the hardware details of a single node.
+func (s *Client) GetNodeHardwareInfo(ctx context.Context, nodeID int) (GetNodeHardwareInfoResponse, error) {
+	return Call[GetNodeHardwareInfoParams, GetNodeHardwareInfoResponse](ctx, s, RPCGetNodeHardwareInfo, GetNodeHardwareInfoParams{
+		NodeID: nodeID,
+	})
+}
 
-	if err != nil {
-		return r, err
-	}
-	return r, nil
+func (s *Client) GetClusterVersionInfo(ctx context.Context) (GetClusterVersionInfoResponse, error) {
+	return Call[GetClusterVersionInfoParams, GetClusterVersionInfoResponse](ctx, s, RPCGetClusterVersionInfo, GetClusterVersionInfoParams{})
 }
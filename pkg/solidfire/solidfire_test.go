@@ -2,27 +2,38 @@ package solidfire_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"path"
 	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/mjavier2k/solidfire-exporter/pkg/solidfire"
 	"github.com/mjavier2k/solidfire-exporter/pkg/testutils"
+	"github.com/stretchr/testify/mock"
 	"gopkg.in/h2non/gock.v1"
 )
 
 var (
-	sfHost        = "https://192.168.1.1"
-	sfRPCEndpoint = "/json-rpc/11.3"
-	sfClient      = solidfire.Client{
+	sfHost          = "https://192.168.1.1"
+	sfRPCEndpoint   = "/json-rpc/11.3"
+	fixtureBasePath = path.Join("..", "..", "test", "fixtures")
+)
+
+// newClient returns a fresh client so the first request id is always 1.
+func newClient() *solidfire.Client {
+	return &solidfire.Client{
 		RPCEndpoint: fmt.Sprintf("%v%v", sfHost, sfRPCEndpoint),
 		HttpClient:  &http.Client{},
 	}
-	fixtureBasePath = path.Join("..", "..", "test", "fixtures")
-)
+}
 
 func TestClient_ListVolumeStats(t *testing.T) {
 	fixture, err := ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, solidfire.RPCListVolumeStats))
@@ -31,7 +42,6 @@ func TestClient_ListVolumeStats(t *testing.T) {
 	}
 	tests := []struct {
 		name    string
-		s       solidfire.Client
 		want    float64
 		wantErr bool
 	}{
@@ -58,7 +68,7 @@ func TestClient_ListVolumeStats(t *testing.T) {
 				}).
 				Reply(200).
 				BodyString(string(fixture))
-			gotRaw, err := sfClient.ListVolumeStats(context.Background())
+			gotRaw, err := newClient().ListVolumeStats(context.Background())
 			got := gotRaw.Result.VolumeStats[0].VolumeSize
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Client.ListVolumeStats() error = %v, wantErr %v", err, tt.wantErr)
@@ -78,7 +88,6 @@ func TestClient_ListVolumes(t *testing.T) {
 	}
 	tests := []struct {
 		name    string
-		s       solidfire.Client
 		want    string
 		wantErr bool
 	}{
@@ -104,7 +113,7 @@ func TestClient_ListVolumes(t *testing.T) {
 				}).
 				Reply(200).
 				BodyString(string(fixture))
-			gotRaw, err := sfClient.ListVolumes(context.Background())
+			gotRaw, err := newClient().ListVolumes(context.Background())
 			got := gotRaw.Result.Volumes[0].Name
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Client.ListVolumes() error = %v, wantErr %v", err, tt.wantErr)
@@ -124,7 +133,6 @@ func TestClient_GetClusterCapacity(t *testing.T) {
 	}
 	tests := []struct {
 		name    string
-		s       solidfire.Client
 		want    float64
 		wantErr bool
 	}{
@@ -148,7 +156,7 @@ func TestClient_GetClusterCapacity(t *testing.T) {
 				}).
 				Reply(200).
 				BodyString(string(fixture))
-			gotRaw, err := sfClient.GetClusterCapacity(context.Background())
+			gotRaw, err := newClient().GetClusterCapacity(context.Background())
 			got := gotRaw.Result.ClusterCapacity.TotalOps
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Client.GetClusterCapacity() error = %v, wantErr %v", err, tt.wantErr)
@@ -168,7 +176,6 @@ func TestClient_ListClusterFaults(t *testing.T) {
 	}
 	tests := []struct {
 		name    string
-		s       solidfire.Client
 		want    float64
 		wantErr bool
 	}{
@@ -196,7 +203,7 @@ func TestClient_ListClusterFaults(t *testing.T) {
 				Reply(200).
 				BodyString(string(fixture))
 
-			gotRaw, err := sfClient.ListClusterFaults(context.Background())
+			gotRaw, err := newClient().ListClusterFaults(context.Background())
 			got := gotRaw.Result.Faults[0].ClusterFaultID
 
 			if (err != nil) != tt.wantErr {
@@ -217,7 +224,6 @@ func TestClient_ListNodeStats(t *testing.T) {
 	}
 	tests := []struct {
 		name    string
-		s       solidfire.Client
 		want    float64
 		wantErr bool
 	}{
@@ -242,7 +248,7 @@ func TestClient_ListNodeStats(t *testing.T) {
 				Reply(200).
 				BodyString(string(fixture))
 
-			gotRaw, err := sfClient.ListNodeStats(context.Background())
+			gotRaw, err := newClient().ListNodeStats(context.Background())
 			got := gotRaw.Result.NodeStats.Nodes[0].CBytesIn
 
 			if (err != nil) != tt.wantErr {
@@ -263,7 +269,6 @@ func TestClient_ListVolumeQoSHistograms(t *testing.T) {
 	}
 	tests := []struct {
 		name    string
-		s       solidfire.Client
 		want    int
 		wantErr bool
 	}{
@@ -290,7 +295,7 @@ func TestClient_ListVolumeQoSHistograms(t *testing.T) {
 				Reply(200).
 				BodyString(string(fixture))
 
-			gotRaw, err := sfClient.ListVolumeQoSHistograms(context.Background())
+			gotRaw, err := newClient().ListVolumeQoSHistograms(context.Background())
 			got := gotRaw.Result.QosHistograms[0].VolumeID
 
 			if (err != nil) != tt.wantErr {
@@ -311,7 +316,6 @@ func TestClient_ListAllNodes(t *testing.T) {
 	}
 	tests := []struct {
 		name    string
-		s       solidfire.Client
 		want    int
 		wantErr bool
 	}{
@@ -336,7 +340,7 @@ func TestClient_ListAllNodes(t *testing.T) {
 				Reply(200).
 				BodyString(string(fixture))
 
-			gotRaw, err := sfClient.ListAllNodes(context.Background())
+			gotRaw, err := newClient().ListAllNodes(context.Background())
 			got := gotRaw.Result.Nodes[0].NodeID
 
 			if (err != nil) != tt.wantErr {
@@ -357,7 +361,6 @@ func TestClient_GetClusterStats(t *testing.T) {
 	}
 	tests := []struct {
 		name    string
-		s       solidfire.Client
 		want    float64
 		wantErr bool
 	}{
@@ -382,7 +385,7 @@ func TestClient_GetClusterStats(t *testing.T) {
 				Reply(200).
 				BodyString(string(fixture))
 
-			gotRaw, err := sfClient.GetClusterStats(context.Background())
+			gotRaw, err := newClient().GetClusterStats(context.Background())
 			got := gotRaw.Result.ClusterStats.ReadOps
 
 			if (err != nil) != tt.wantErr {
@@ -403,7 +406,6 @@ func TestClient_GetClusterFullThreshold(t *testing.T) {
 	}
 	tests := []struct {
 		name    string
-		s       solidfire.Client
 		want    float64
 		wantErr bool
 	}{
@@ -428,7 +430,7 @@ func TestClient_GetClusterFullThreshold(t *testing.T) {
 				Reply(200).
 				BodyString(string(fixture))
 
-			gotRaw, err := sfClient.GetClusterFullThreshold(context.Background())
+			gotRaw, err := newClient().GetClusterFullThreshold(context.Background())
 			got := gotRaw.Result.Stage2AwareThreshold
 
 			if (err != nil) != tt.wantErr {
@@ -449,7 +451,6 @@ func TestClient_ListAccounts(t *testing.T) {
 	}
 	tests := []struct {
 		name    string
-		s       solidfire.Client
 		want    string
 		wantErr bool
 	}{
@@ -473,7 +474,7 @@ func TestClient_ListAccounts(t *testing.T) {
 				}).
 				Reply(200).
 				BodyString(string(fixture))
-			gotRaw, err := sfClient.ListAccounts(context.Background())
+			gotRaw, err := newClient().ListAccounts(context.Background())
 			got := gotRaw.Result.Accounts[0].Username
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Client.ListAccounts() error = %v, wantErr %v", err, tt.wantErr)
@@ -493,7 +494,6 @@ func TestClient_ListInitiators(t *testing.T) {
 	}
 	tests := []struct {
 		name    string
-		s       solidfire.Client
 		want    string
 		wantErr bool
 	}{
@@ -517,7 +517,7 @@ func TestClient_ListInitiators(t *testing.T) {
 				}).
 				Reply(200).
 				BodyString(string(fixture))
-			gotRaw, err := sfClient.ListInitiators(context.Background())
+			gotRaw, err := newClient().ListInitiators(context.Background())
 			got := gotRaw.Result.Initiators[0].InitiatorName
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Client.ListInitiators() error = %v, wantErr %v", err, tt.wantErr)
@@ -537,7 +537,6 @@ func TestClient_ListVirtualVolumeTasks(t *testing.T) {
 	}
 	tests := []struct {
 		name    string
-		s       solidfire.Client
 		want    string
 		wantErr bool
 	}{
@@ -560,7 +559,7 @@ func TestClient_ListVirtualVolumeTasks(t *testing.T) {
 				}).
 				Reply(200).
 				BodyString(string(fixture))
-			gotRaw, err := sfClient.ListVirtualVolumeTasks(context.Background())
+			gotRaw, err := newClient().ListVirtualVolumeTasks(context.Background())
 			got := gotRaw.Result.Tasks[0].VirtualVolumeTaskID
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Client.ListVirtualVolumeTasks() error = %v, wantErr %v", err, tt.wantErr)
@@ -580,7 +579,6 @@ func TestClient_ListAsyncResults(t *testing.T) {
 	}
 	tests := []struct {
 		name    string
-		s       solidfire.Client
 		want    int64
 		wantErr bool
 	}{
@@ -603,7 +601,7 @@ func TestClient_ListAsyncResults(t *testing.T) {
 				}).
 				Reply(200).
 				BodyString(string(fixture))
-			gotRaw, err := sfClient.ListAsyncResults(context.Background())
+			gotRaw, err := newClient().ListAsyncResults(context.Background())
 			got := gotRaw.Result.AsyncHandles[0].AsyncResultID
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Client.ListAsyncResults() error = %v, wantErr %v", err, tt.wantErr)
@@ -623,7 +621,6 @@ func TestClient_ListBulkVolumeJobs(t *testing.T) {
 	}
 	tests := []struct {
 		name    string
-		s       solidfire.Client
 		want    int64
 		wantErr bool
 	}{
@@ -646,7 +643,7 @@ func TestClient_ListBulkVolumeJobs(t *testing.T) {
 				}).
 				Reply(200).
 				BodyString(string(fixture))
-			gotRaw, err := sfClient.ListBulkVolumeJobs(context.Background())
+			gotRaw, err := newClient().ListBulkVolumeJobs(context.Background())
 			got := gotRaw.Result.BulkVolumeJobs[0].BulkVolumeID
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Client.ListBulkVolumeJobs() error = %v, wantErr %v", err, tt.wantErr)
@@ -666,7 +663,6 @@ func TestClient_ListVolumeAccessGroups(t *testing.T) {
 	}
 	tests := []struct {
 		name    string
-		s       solidfire.Client
 		want    string
 		wantErr bool
 	}{
@@ -690,7 +686,7 @@ func TestClient_ListVolumeAccessGroups(t *testing.T) {
 				}).
 				Reply(200).
 				BodyString(string(fixture))
-			gotRaw, err := sfClient.ListVolumeAccessGroups(context.Background())
+			gotRaw, err := newClient().ListVolumeAccessGroups(context.Background())
 			got := gotRaw.Result.VolumeAccessGroups[0].Name
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Client.ListVolumeAccessGroups() error = %v, wantErr %v", err, tt.wantErr)
@@ -702,3 +698,1141 @@ func TestClient_ListVolumeAccessGroups(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_GetClusterInfo(t *testing.T) {
+	fixture, err := ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, solidfire.RPCGetClusterInfo))
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Cluster name should match fixture",
+			want: "sfcluster01",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+			// gock.Observe(gock.DumpRequest)
+			gock.New(sfHost).
+				Post(sfRPCEndpoint).
+				MatchType("json").
+				JSON(solidfire.RPCBody{
+					ID:     1,
+					Method: solidfire.RPCGetClusterInfo,
+					Params: solidfire.GetClusterInfoParams{},
+				}).
+				Reply(200).
+				BodyString(string(fixture))
+			gotRaw, err := newClient().GetClusterInfo(context.Background())
+			got := gotRaw.Result.ClusterInfo.Name
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.GetClusterInfo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Client.GetClusterInfo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ListQoSPolicies(t *testing.T) {
+	fixture, err := ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, solidfire.RPCListQoSPolicies))
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Name of first QoS policy should match fixture",
+			want: "gold",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+			// gock.Observe(gock.DumpRequest)
+			gock.New(sfHost).
+				Post(sfRPCEndpoint).
+				MatchType("json").
+				JSON(solidfire.RPCBody{
+					ID:     1,
+					Method: solidfire.RPCListQoSPolicies,
+					Params: solidfire.ListQoSPoliciesParams{},
+				}).
+				Reply(200).
+				BodyString(string(fixture))
+			gotRaw, err := newClient().ListQoSPolicies(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.ListQoSPolicies() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			got := gotRaw.Result.QoSPolicies[0].Name
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Client.ListQoSPolicies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ListDeletedVolumes(t *testing.T) {
+	fixture, err := ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, solidfire.RPCListDeletedVolumes))
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Purge time of first deleted volume should match fixture",
+			want: "2021-04-20T05:30:24Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+			// gock.Observe(gock.DumpRequest)
+			gock.New(sfHost).
+				Post(sfRPCEndpoint).
+				MatchType("json").
+				JSON(solidfire.RPCBody{
+					ID:     1,
+					Method: solidfire.RPCListDeletedVolumes,
+					Params: solidfire.ListDeletedVolumesParams{},
+				}).
+				Reply(200).
+				BodyString(string(fixture))
+			gotRaw, err := newClient().ListDeletedVolumes(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.ListDeletedVolumes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			got := gotRaw.Result.Volumes[0].PurgeTime
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Client.ListDeletedVolumes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ListSnapshots(t *testing.T) {
+	fixture, err := ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, solidfire.RPCListSnapshots))
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Name of first snapshot should match fixture",
+			want: "daily-2021-04-01",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+			// gock.Observe(gock.DumpRequest)
+			gock.New(sfHost).
+				Post(sfRPCEndpoint).
+				MatchType("json").
+				JSON(solidfire.RPCBody{
+					ID:     1,
+					Method: solidfire.RPCListSnapshots,
+					Params: solidfire.ListSnapshotsParams{},
+				}).
+				Reply(200).
+				BodyString(string(fixture))
+			gotRaw, err := newClient().ListSnapshots(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.ListSnapshots() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			got := gotRaw.Result.Snapshots[0].Name
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Client.ListSnapshots() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ListGroupSnapshots(t *testing.T) {
+	fixture, err := ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, solidfire.RPCListGroupSnapshots))
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+	tests := []struct {
+		name    string
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Members of first group snapshot should match fixture",
+			want: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+			// gock.Observe(gock.DumpRequest)
+			gock.New(sfHost).
+				Post(sfRPCEndpoint).
+				MatchType("json").
+				JSON(solidfire.RPCBody{
+					ID:     1,
+					Method: solidfire.RPCListGroupSnapshots,
+					Params: solidfire.ListGroupSnapshotsParams{},
+				}).
+				Reply(200).
+				BodyString(string(fixture))
+			gotRaw, err := newClient().ListGroupSnapshots(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.ListGroupSnapshots() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			got := len(gotRaw.Result.GroupSnapshots[0].Members)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Client.ListGroupSnapshots() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ListSchedules(t *testing.T) {
+	fixture, err := ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, solidfire.RPCListSchedules))
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Name of first schedule should match fixture",
+			want: "daily-snap",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+			// gock.Observe(gock.DumpRequest)
+			gock.New(sfHost).
+				Post(sfRPCEndpoint).
+				MatchType("json").
+				JSON(solidfire.RPCBody{
+					ID:     1,
+					Method: solidfire.RPCListSchedules,
+					Params: solidfire.ListSchedulesParams{},
+				}).
+				Reply(200).
+				BodyString(string(fixture))
+			gotRaw, err := newClient().ListSchedules(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.ListSchedules() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			got := gotRaw.Result.Schedules[0].ScheduleName
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Client.ListSchedules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ListClusterPairs(t *testing.T) {
+	fixture, err := ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, solidfire.RPCListClusterPairs))
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Name of first cluster pair should match fixture",
+			want: "dr-cluster",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+			// gock.Observe(gock.DumpRequest)
+			gock.New(sfHost).
+				Post(sfRPCEndpoint).
+				MatchType("json").
+				JSON(solidfire.RPCBody{
+					ID:     1,
+					Method: solidfire.RPCListClusterPairs,
+					Params: solidfire.ListClusterPairsParams{},
+				}).
+				Reply(200).
+				BodyString(string(fixture))
+			gotRaw, err := newClient().ListClusterPairs(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.ListClusterPairs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			got := gotRaw.Result.ClusterPairs[0].ClusterName
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Client.ListClusterPairs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ListActivePairedVolumes(t *testing.T) {
+	fixture, err := ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, solidfire.RPCListActivePairedVolumes))
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Replication mode of first paired volume should match fixture",
+			want: "Async",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+			// gock.Observe(gock.DumpRequest)
+			gock.New(sfHost).
+				Post(sfRPCEndpoint).
+				MatchType("json").
+				JSON(solidfire.RPCBody{
+					ID:     1,
+					Method: solidfire.RPCListActivePairedVolumes,
+					Params: solidfire.ListActivePairedVolumesParams{},
+				}).
+				Reply(200).
+				BodyString(string(fixture))
+			gotRaw, err := newClient().ListActivePairedVolumes(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.ListActivePairedVolumes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			got := gotRaw.Result.Volumes[0].VolumePairs[0].RemoteReplication.Mode
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Client.ListActivePairedVolumes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ListSnapMirrorEndpoints(t *testing.T) {
+	fixture, err := ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, solidfire.RPCListSnapMirrorEndpoints))
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Cluster name of first SnapMirror endpoint should match fixture",
+			want: "ontap-dr",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+			// gock.Observe(gock.DumpRequest)
+			gock.New(sfHost).
+				Post(sfRPCEndpoint).
+				MatchType("json").
+				JSON(solidfire.RPCBody{
+					ID:     1,
+					Method: solidfire.RPCListSnapMirrorEndpoints,
+					Params: solidfire.ListSnapMirrorEndpointsParams{},
+				}).
+				Reply(200).
+				BodyString(string(fixture))
+			gotRaw, err := newClient().ListSnapMirrorEndpoints(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.ListSnapMirrorEndpoints() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			got := gotRaw.Result.SnapMirrorEndpoints[0].ClusterName
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Client.ListSnapMirrorEndpoints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ListSnapMirrorRelationships(t *testing.T) {
+	fixture, err := ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, solidfire.RPCListSnapMirrorRelationships))
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+	tests := []struct {
+		name    string
+		want    int64
+		wantErr bool
+	}{
+		{
+			name: "Lag time of first SnapMirror relationship should match fixture",
+			want: 3725,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+			// gock.Observe(gock.DumpRequest)
+			gock.New(sfHost).
+				Post(sfRPCEndpoint).
+				MatchType("json").
+				JSON(solidfire.RPCBody{
+					ID:     1,
+					Method: solidfire.RPCListSnapMirrorRelationships,
+					Params: solidfire.ListSnapMirrorRelationshipsParams{},
+				}).
+				Reply(200).
+				BodyString(string(fixture))
+			gotRaw, err := newClient().ListSnapMirrorRelationships(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.ListSnapMirrorRelationships() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			got := gotRaw.Result.SnapMirrorRelationships[0].Lagtime
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Client.ListSnapMirrorRelationships() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_GetClusterVersionInfo(t *testing.T) {
+	fixture, err := ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, solidfire.RPCGetClusterVersionInfo))
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Cluster version should match fixture",
+			want: "12.3.0.958",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+			// gock.Observe(gock.DumpRequest)
+			gock.New(sfHost).
+				Post(sfRPCEndpoint).
+				MatchType("json").
+				JSON(solidfire.RPCBody{
+					ID:     1,
+					Method: solidfire.RPCGetClusterVersionInfo,
+					Params: solidfire.GetClusterVersionInfoParams{},
+				}).
+				Reply(200).
+				BodyString(string(fixture))
+			gotRaw, err := newClient().GetClusterVersionInfo(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.GetClusterVersionInfo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			got := gotRaw.Result.ClusterVersion
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Client.GetClusterVersionInfo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_GetClusterMasterNodeID(t *testing.T) {
+	fixture, err := ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, solidfire.RPCGetClusterMasterNodeID))
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+	tests := []struct {
+		name    string
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Master node ID should match fixture",
+			want: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+			// gock.Observe(gock.DumpRequest)
+			gock.New(sfHost).
+				Post(sfRPCEndpoint).
+				MatchType("json").
+				JSON(solidfire.RPCBody{
+					ID:     1,
+					Method: solidfire.RPCGetClusterMasterNodeID,
+					Params: solidfire.GetClusterMasterNodeIDParams{},
+				}).
+				Reply(200).
+				BodyString(string(fixture))
+			gotRaw, err := newClient().GetClusterMasterNodeID(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.GetClusterMasterNodeID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			got := gotRaw.Result.NodeID
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Client.GetClusterMasterNodeID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_GetIpmiInfo(t *testing.T) {
+	fixture, err := ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, solidfire.RPCGetIpmiInfo))
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Name of first sensor should match fixture",
+			want: "SYS_FAN1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+			// gock.Observe(gock.DumpRequest)
+			gock.New(sfHost).
+				Post(sfRPCEndpoint).
+				MatchType("json").
+				JSON(solidfire.RPCBody{
+					ID:     1,
+					Method: solidfire.RPCGetIpmiInfo,
+					Params: solidfire.GetIpmiInfoParams{},
+				}).
+				Reply(200).
+				BodyString(string(fixture))
+			gotRaw, err := newClient().GetIpmiInfo(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.GetIpmiInfo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			got := gotRaw.Result.IpmiInfo.SensorReadings[0].SensorName
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Client.GetIpmiInfo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_GetNodeHardwareInfo(t *testing.T) {
+	fixture, err := ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, solidfire.RPCGetNodeHardwareInfo))
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+	tests := []struct {
+		name    string
+		nodeID  int
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "BMC firmware revision should match fixture",
+			nodeID: 1,
+			want:   "3.25",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+			// gock.Observe(gock.DumpRequest)
+			gock.New(sfHost).
+				Post(sfRPCEndpoint).
+				MatchType("json").
+				JSON(solidfire.RPCBody{
+					ID:     1,
+					Method: solidfire.RPCGetNodeHardwareInfo,
+					Params: solidfire.GetNodeHardwareInfoParams{NodeID: tt.nodeID},
+				}).
+				Reply(200).
+				BodyString(string(fixture))
+			gotRaw, err := newClient().GetNodeHardwareInfo(context.Background(), tt.nodeID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.GetNodeHardwareInfo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			got := gotRaw.Result.NodeHardwareInfo.BmcFirmwareRevision
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Client.GetNodeHardwareInfo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_APIError(t *testing.T) {
+	tests := []struct {
+		name             string
+		body             string
+		wantName         string
+		wantCode         int
+		permissionDenied bool
+	}{
+		{
+			name:     "unknown API method",
+			body:     `{"id": 1, "error": {"name": "xUnknownAPIMethod", "code": 500, "message": "Unknown method"}}`,
+			wantName: solidfire.ErrNameUnknownAPIMethod,
+			wantCode: 500,
+		},
+		{
+			name:             "permission denied",
+			body:             `{"id": 1, "error": {"name": "xPermissionDenied", "code": 500, "message": "Permission denied"}}`,
+			wantName:         solidfire.ErrNamePermissionDenied,
+			wantCode:         500,
+			permissionDenied: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+			gock.New(sfHost).
+				Post(sfRPCEndpoint).
+				Reply(200).
+				BodyString(tt.body)
+			_, err := newClient().ListVolumeQoSHistograms(context.Background())
+			var apiErr *solidfire.APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Client.ListVolumeQoSHistograms() error = %v, want *solidfire.APIError", err)
+			}
+			if apiErr.Name != tt.wantName || apiErr.Code != tt.wantCode || apiErr.Method != solidfire.RPCListVolumeQoSHistograms {
+				t.Errorf("Client.ListVolumeQoSHistograms() error = %+v, want name %v code %v", apiErr, tt.wantName, tt.wantCode)
+			}
+			if got := solidfire.IsPermissionDenied(err); got != tt.permissionDenied {
+				t.Errorf("IsPermissionDenied() = %v, want %v", got, tt.permissionDenied)
+			}
+		})
+	}
+}
+
+func TestEndpointForTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		want    string
+		wantErr bool
+	}{
+		{name: "bare MVIP", target: "10.0.0.1", want: "https://10.0.0.1/json-rpc/11.3"},
+		{name: "scheme and port without path", target: "http://10.0.0.1:8080", want: "http://10.0.0.1:8080/json-rpc/11.3"},
+		{name: "full endpoint is kept", target: "https://mvip.example.com/json-rpc/12.2", want: "https://mvip.example.com/json-rpc/12.2"},
+		{name: "empty target", target: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := solidfire.EndpointForTarget(tt.target, "11.3")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EndpointForTarget() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("EndpointForTarget() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCall(t *testing.T) {
+	type getLimitsParams struct{}
+	type getLimitsResponse struct {
+		ID     int `json:"id"`
+		Result struct {
+			VolumesPerAccountCountMax int `json:"volumesPerAccountCountMax"`
+		} `json:"result"`
+	}
+
+	defer gock.Off()
+	for _, id := range []int{1, 2} {
+		gock.New(sfHost).
+			Post(sfRPCEndpoint).
+			MatchType("json").
+			JSON(solidfire.RPCBody{
+				ID:     id,
+				Method: "GetLimits",
+				Params: getLimitsParams{},
+			}).
+			Reply(200).
+			BodyString(fmt.Sprintf(`{"id": %d, "result": {"volumesPerAccountCountMax": 2000}}`, id))
+	}
+
+	c := newClient()
+	for i := 0; i < 2; i++ {
+		got, err := solidfire.Call[getLimitsParams, getLimitsResponse](context.Background(), c, "GetLimits", getLimitsParams{})
+		if err != nil {
+			t.Fatalf("Call() error = %v", err)
+		}
+		if got.ID != i+1 || got.Result.VolumesPerAccountCountMax != 2000 {
+			t.Errorf("Call() = %+v, want id %v and volumesPerAccountCountMax 2000", got, i+1)
+		}
+	}
+	if !gock.IsDone() {
+		t.Errorf("Call() did not send a unique id per request")
+	}
+}
+
+func TestCall_MismatchedID(t *testing.T) {
+	defer gock.Off()
+	gock.New(sfHost).
+		Post(sfRPCEndpoint).
+		Reply(200).
+		BodyString(`{"id": 42, "result": {}}`)
+	_, err := newClient().GetClusterInfo(context.Background())
+	if err == nil {
+		t.Errorf("Client.GetClusterInfo() error = nil, want mismatched id error")
+	}
+}
+
+func TestClient_Retry(t *testing.T) {
+	retry := solidfire.RetryPolicy{
+		MaxAttempts:          3,
+		BaseBackoff:          time.Millisecond,
+		MaxBackoff:           5 * time.Millisecond,
+		RetryableErrors:      solidfire.DefaultRetryErrorNames,
+		RetryableStatusCodes: solidfire.DefaultRetryStatusCodes,
+	}
+	tests := []struct {
+		name      string
+		failures  []func(*gock.Response)
+		wantErr   bool
+		wantCalls int
+	}{
+		{
+			name: "503 is retried",
+			failures: []func(*gock.Response){
+				func(r *gock.Response) { r.Status(503) },
+			},
+			wantCalls: 2,
+		},
+		{
+			name: "xServiceUnavailable is retried",
+			failures: []func(*gock.Response){
+				func(r *gock.Response) {
+					r.Status(200).BodyString(`{"error": {"name": "xServiceUnavailable", "code": 500, "message": "failover"}}`)
+				},
+				func(r *gock.Response) {
+					r.Status(200).BodyString(`{"error": {"name": "xDBConnectionLoss", "code": 500, "message": "failover"}}`)
+				},
+			},
+			wantCalls: 3,
+		},
+		{
+			name: "attempts are capped",
+			failures: []func(*gock.Response){
+				func(r *gock.Response) { r.Status(503) },
+				func(r *gock.Response) { r.Status(503) },
+				func(r *gock.Response) { r.Status(503) },
+			},
+			wantErr:   true,
+			wantCalls: 3,
+		},
+		{
+			name: "permission denied is not retried",
+			failures: []func(*gock.Response){
+				func(r *gock.Response) {
+					r.Status(200).BodyString(`{"error": {"name": "xPermissionDenied", "code": 500, "message": "denied"}}`)
+				},
+			},
+			wantErr:   true,
+			wantCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+			for _, fail := range tt.failures {
+				fail(gock.New(sfHost).Post(sfRPCEndpoint).Reply(200))
+			}
+			gock.New(sfHost).
+				Post(sfRPCEndpoint).
+				Reply(200).
+				BodyString(`{"result": {"clusterInfo": {"name": "sfcluster01"}}}`)
+
+			c := newClient()
+			c.Retry = retry
+			got, err := c.GetClusterInfo(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Client.GetClusterInfo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.Result.ClusterInfo.Name != "sfcluster01" {
+				t.Errorf("Client.GetClusterInfo() = %+v, want name sfcluster01", got)
+			}
+			if pending := len(gock.Pending()); 1+len(tt.failures)-pending != tt.wantCalls {
+				t.Errorf("Client.GetClusterInfo() made %d calls, want %d", 1+len(tt.failures)-pending, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestClient_RetryRespectsDeadline(t *testing.T) {
+	defer gock.Off()
+	gock.New(sfHost).Post(sfRPCEndpoint).Times(2).Reply(503)
+
+	c := newClient()
+	c.Retry = solidfire.RetryPolicy{
+		MaxAttempts:          2,
+		BaseBackoff:          time.Minute,
+		MaxBackoff:           time.Minute,
+		RetryableStatusCodes: []int{503},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := c.GetClusterInfo(ctx)
+	var statusErr *solidfire.HTTPStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != 503 {
+		t.Errorf("Client.GetClusterInfo() error = %v, want HTTP 503", err)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("Client.GetClusterInfo() took %v, want it to give up before sleeping past the deadline", elapsed)
+	}
+}
+
+func TestClient_NegotiateAPIVersion(t *testing.T) {
+	getAPI, err := ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, solidfire.RPCGetAPI))
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+	versionInfo, err := ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, solidfire.RPCGetClusterVersionInfo))
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+	tests := []struct {
+		name     string
+		endpoint string
+		mocks    func()
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "endpoint with version is used as is",
+			endpoint: sfHost + "/json-rpc/11.3",
+			mocks:    func() {},
+			want:     "11.3",
+		},
+		{
+			name:     "bare MVIP negotiates with GetAPI",
+			endpoint: sfHost,
+			mocks: func() {
+				gock.New(sfHost).Post("/json-rpc/1.0").
+					MatchType("json").
+					JSON(solidfire.RPCBody{ID: 1, Method: solidfire.RPCGetAPI, Params: solidfire.GetAPIParams{}}).
+					Reply(200).
+					BodyString(string(getAPI))
+			},
+			want: "12.3",
+		},
+		{
+			name:     "falls back to GetClusterVersionInfo",
+			endpoint: sfHost + "/",
+			mocks: func() {
+				gock.New(sfHost).Post("/json-rpc/1.0").
+					MatchType("json").
+					JSON(solidfire.RPCBody{ID: 1, Method: solidfire.RPCGetAPI, Params: solidfire.GetAPIParams{}}).
+					Reply(200).
+					BodyString(`{"id": 1, "error": {"name": "xUnknownAPIMethod", "code": 500, "message": "Unknown method"}}`)
+				gock.New(sfHost).Post("/json-rpc/1.0").
+					Reply(200).
+					BodyString(strings.NewReplacer(`"id": 1`, `"id": 2`, `"clusterAPIVersion": "12.3"`, `"clusterAPIVersion": "12.4"`).Replace(string(versionInfo)))
+			},
+			want: "12.3",
+		},
+		{
+			name:     "unsupported cluster",
+			endpoint: sfHost,
+			mocks: func() {
+				gock.New(sfHost).Post("/json-rpc/1.0").
+					Reply(200).
+					BodyString(`{"id": 1, "result": {"currentVersion": "10.0", "supportedVersions": ["1.0", "10.0"]}}`)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+			tt.mocks()
+			c := &solidfire.Client{RPCEndpoint: tt.endpoint, HttpClient: &http.Client{}}
+			got, err := c.NegotiateAPIVersion(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Client.NegotiateAPIVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want || c.APIVersion() != tt.want {
+				t.Errorf("Client.NegotiateAPIVersion() = %v, APIVersion() = %v, want %v", got, c.APIVersion(), tt.want)
+			}
+			if !tt.wantErr && tt.want != "" && c.RPCEndpoint != sfHost+"/json-rpc/"+tt.want {
+				t.Errorf("Client.RPCEndpoint = %v, want %v", c.RPCEndpoint, sfHost+"/json-rpc/"+tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_NegotiateAPIVersion_Concurrent(t *testing.T) {
+	getAPI, err := ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, solidfire.RPCGetAPI))
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+	tests := []struct {
+		name         string
+		status       int
+		wantRequests int32
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:         "callers share one negotiation",
+			status:       http.StatusOK,
+			wantRequests: 1,
+			want:         "12.3",
+		},
+		{
+			// GetAPI and the GetClusterVersionInfo fallback of one negotiation
+			name:         "callers share the negotiation error",
+			status:       http.StatusServiceUnavailable,
+			wantRequests: 2,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&requests, 1)
+				time.Sleep(50 * time.Millisecond)
+				w.WriteHeader(tt.status)
+				w.Write(getAPI)
+			}))
+			defer server.Close()
+
+			c := &solidfire.Client{RPCEndpoint: server.URL, HttpClient: &http.Client{}}
+			var wg sync.WaitGroup
+			errs := make([]error, 10)
+			for i := range errs {
+				i := i
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, errs[i] = c.NegotiateAPIVersion(context.Background())
+				}()
+			}
+			wg.Wait()
+
+			if got := atomic.LoadInt32(&requests); got != tt.wantRequests {
+				t.Errorf("negotiation requests = %v, want %v", got, tt.wantRequests)
+			}
+			for _, err := range errs {
+				if (err != nil) != tt.wantErr {
+					t.Errorf("Client.NegotiateAPIVersion() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+			if got := c.APIVersion(); got != tt.want {
+				t.Errorf("Client.APIVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_NegotiateAPIVersion_CancelledCaller(t *testing.T) {
+	getAPI, err := ioutil.ReadFile(testutils.ResolveFixturePath(fixtureBasePath, solidfire.RPCGetAPI))
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		time.Sleep(50 * time.Millisecond)
+		w.Write(getAPI)
+	}))
+	defer server.Close()
+
+	c := &solidfire.Client{RPCEndpoint: server.URL, HttpClient: &http.Client{}}
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := c.NegotiateAPIVersion(ctx)
+		first <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	waiter := make(chan error)
+	go func() {
+		_, err := c.NegotiateAPIVersion(context.Background())
+		waiter <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	if err := <-first; err != context.Canceled {
+		t.Errorf("cancelled Client.NegotiateAPIVersion() error = %v, want %v", err, context.Canceled)
+	}
+	if err := <-waiter; err != nil {
+		t.Errorf("waiting Client.NegotiateAPIVersion() error = %v", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("negotiation requests = %v, want 1", got)
+	}
+	if got := c.APIVersion(); got != "12.3" {
+		t.Errorf("Client.APIVersion() = %v, want %v", got, "12.3")
+	}
+}
+
+type observedCall struct {
+	method solidfire.RPC
+	kind   string
+}
+
+type recordingObserver struct {
+	calls []observedCall
+}
+
+func (o *recordingObserver) ObserveRPC(method solidfire.RPC, duration time.Duration, err error) {
+	kind := ""
+	if err != nil {
+		kind = solidfire.ErrorKind(err)
+	}
+	o.calls = append(o.calls, observedCall{method: method, kind: kind})
+}
+
+func TestClient_Observer(t *testing.T) {
+	defer gock.Off()
+	gock.New(sfHost).Post(sfRPCEndpoint).Reply(503)
+	gock.New(sfHost).
+		Post(sfRPCEndpoint).
+		Reply(200).
+		BodyString(`{"error": {"name": "xServiceUnavailable", "code": 500, "message": "failover"}}`)
+	gock.New(sfHost).Post(sfRPCEndpoint).Reply(200).BodyString(`{"result": {}}`)
+
+	observer := &recordingObserver{}
+	c := newClient()
+	c.Observer = observer
+	c.Retry = solidfire.RetryPolicy{
+		MaxAttempts:          3,
+		RetryableErrors:      solidfire.DefaultRetryErrorNames,
+		RetryableStatusCodes: solidfire.DefaultRetryStatusCodes,
+	}
+	if _, err := c.GetClusterInfo(context.Background()); err != nil {
+		t.Fatalf("Client.GetClusterInfo() error = %v", err)
+	}
+	want := []observedCall{
+		{method: solidfire.RPCGetClusterInfo, kind: "http_503"},
+		{method: solidfire.RPCGetClusterInfo, kind: solidfire.ErrNameServiceUnavailable},
+		{method: solidfire.RPCGetClusterInfo, kind: ""},
+	}
+	if !reflect.DeepEqual(observer.calls, want) {
+		t.Errorf("observed calls = %+v, want %+v", observer.calls, want)
+	}
+}
+
+type cacheCounter struct {
+	hits, misses int
+}
+
+func (c *cacheCounter) ObserveCache(method solidfire.RPC, hit bool) {
+	if hit {
+		c.hits++
+	} else {
+		c.misses++
+	}
+}
+
+func TestCachedClient(t *testing.T) {
+	mockClient := new(testutils.MockSolidfireClient)
+	nodes := solidfire.ListAllNodesResponse{ID: 1}
+	mockClient.On("ListAllNodes", mock.Anything).Return(nodes, nil)
+	mockClient.On("ListDrives", mock.Anything).Return(solidfire.ListDrivesResponse{}, errors.New("boom"))
+	mockClient.On("GetClusterStats", mock.Anything).Return(solidfire.GetClusterStatsResponse{}, nil)
+
+	counter := &cacheCounter{}
+	c := solidfire.NewCachedClient(mockClient, map[solidfire.RPC]time.Duration{
+		solidfire.RPCListAllNodes: time.Hour,
+		solidfire.RPCListDrives:   time.Hour,
+	}, counter)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		got, err := c.ListAllNodes(ctx)
+		if err != nil || !reflect.DeepEqual(got, nodes) {
+			t.Fatalf("CachedClient.ListAllNodes() = %v, %v", got, err)
+		}
+	}
+	mockClient.AssertNumberOfCalls(t, "ListAllNodes", 1)
+
+	if _, err := c.ListAllNodes(solidfire.WithForceRefresh(ctx)); err != nil {
+		t.Fatalf("CachedClient.ListAllNodes() error = %v", err)
+	}
+	mockClient.AssertNumberOfCalls(t, "ListAllNodes", 2)
+
+	// errors are not cached
+	c.ListDrives(ctx)
+	c.ListDrives(ctx)
+	mockClient.AssertNumberOfCalls(t, "ListDrives", 2)
+
+	// methods without a TTL are passed through and not observed
+	c.GetClusterStats(ctx)
+	c.GetClusterStats(ctx)
+	mockClient.AssertNumberOfCalls(t, "GetClusterStats", 2)
+
+	if counter.hits != 2 || counter.misses != 4 {
+		t.Errorf("cache hits = %v, misses = %v, want 2 and 4", counter.hits, counter.misses)
+	}
+}
+
+func TestParseCacheTTLs(t *testing.T) {
+	got, err := solidfire.ParseCacheTTLs(map[string]string{"listallnodes": "10m", "ListAccounts": "1h"})
+	if err != nil {
+		t.Fatalf("ParseCacheTTLs() error = %v", err)
+	}
+	want := map[solidfire.RPC]time.Duration{
+		solidfire.RPCListAllNodes: 10 * time.Minute,
+		solidfire.RPCListAccounts: time.Hour,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseCacheTTLs() = %v, want %v", got, want)
+	}
+	if _, err := solidfire.ParseCacheTTLs(map[string]string{"GetAPI": "1m"}); err == nil {
+		t.Errorf("ParseCacheTTLs() with an unknown method should fail")
+	}
+	if _, err := solidfire.ParseCacheTTLs(map[string]string{"ListAccounts": "soon"}); err == nil {
+		t.Errorf("ParseCacheTTLs() with an invalid duration should fail")
+	}
+}
@@ -3,6 +3,8 @@ package solidfire
 import (
 	"context"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -24,11 +26,48 @@ const (
 	HTTPClientTimeout        string = "client.timeout"
 	DefaultHTTPClientTimeout int    = 30
 
+	RetryMaxAttempts        string        = "client.retry.max_attempts"
+	DefaultRetryMaxAttempts int           = 3
+	RetryBaseBackoff        string        = "client.retry.base_backoff"
+	DefaultRetryBaseBackoff time.Duration = 500 * time.Millisecond
+	RetryMaxBackoff         string        = "client.retry.max_backoff"
+	DefaultRetryMaxBackoff  time.Duration = 5 * time.Second
+	RetryErrorNames         string        = "client.retry.error_names"
+	RetryStatusCodes        string        = "client.retry.status_codes"
+
 	CollectTimeout        string = "collect.timeout"
 	DefaultCollectTimeout int    = 60
 
+	PollEnabled         string        = "poll.enabled"
+	DefaultPollEnabled  bool          = false
+	PollInterval        string        = "poll.interval"
+	DefaultPollInterval time.Duration = 60 * time.Second
+	PollIntervals       string        = "poll.intervals"
+
+	CacheTTLs string = "cache.ttls"
+
+	VolumesInclude string = "volumes.include"
+	VolumesExclude string = "volumes.exclude"
+	VolumesLabels  string = "volumes.labels"
+
+	VolumesIdleAfter        string        = "volumes.idle_after"
+	DefaultVolumesIdleAfter time.Duration = 30 * 24 * time.Hour
+
 	ConfigFile        string = "config"
 	DefaultConfigFile string = "config.yaml"
+
+	Clusters string = "clusters"
+	// LocalCluster is the /report/volumes key of the cluster configured under
+	// client.* when no clusters are listed.
+	LocalCluster string = "local"
+
+	Modules       string = "modules"
+	DefaultModule string = "default"
+)
+
+var (
+	DefaultRetryErrorNames  = []string{ErrNameServiceUnavailable, ErrNameDBConnectionLoss}
+	DefaultRetryStatusCodes = []int{502, 503, 504}
 )
 
 type Client struct {
@@ -36,7 +75,55 @@ type Client struct {
 	Password    string
 	RPCEndpoint string
 	HttpClient  *http.Client
+	Retry       RetryPolicy
+	Observer    Observer
+
+	// mu guards RPCEndpoint, apiVersion and negotiation. negotiation is set
+	// while the API version is being negotiated.
+	mu          sync.Mutex
+	apiVersion  string
+	negotiation *negotiation
+
+	// requestID is incremented for every call so each JSON-RPC request
+	// carries a unique id.
+	requestID atomic.Int64
+}
+type ClientOpts struct {
+	Endpoint string
+	Username string
+	Password string
+	Insecure bool
+	Timeout  time.Duration
+	Retry    RetryPolicy
+	Observer Observer
 }
+
+// Module is a named set of credentials and client settings used by the
+// /probe endpoint, selected with the ?module= query parameter. Targets lists
+// the hosts, host:port pairs or CIDR ranges the module's credentials may be
+// sent to; an empty list allows any target.
+type Module struct {
+	Username   string   `mapstructure:"username"`
+	Password   string   `mapstructure:"password"`
+	Insecure   bool     `mapstructure:"insecure"`
+	Timeout    int      `mapstructure:"timeout"`
+	APIVersion string   `mapstructure:"api_version"`
+	Targets    []string `mapstructure:"targets"`
+}
+
+// ClusterConfig is one entry of the clusters: list. Every metric collected
+// from it carries an sfcluster label set to Name, or to the name reported by
+// GetClusterInfo when Name is empty. Insecure is a pointer so an omitted
+// setting can fall back to client.insecure_ssl.
+type ClusterConfig struct {
+	Name     string `mapstructure:"name"`
+	Endpoint string `mapstructure:"endpoint"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	Insecure *bool  `mapstructure:"insecure"`
+	Timeout  int    `mapstructure:"timeout"`
+}
+
 type Interface interface {
 	GetClusterCapacity(ctx context.Context) (GetClusterCapacityResponse, error)
 	GetClusterFullThreshold(ctx context.Context) (GetClusterFullThresholdResponse, error)
@@ -55,6 +142,21 @@ type Interface interface {
 	ListVirtualVolumeTasks(ctx context.Context) (ListVirtualVolumeTasksResponse, error)
 	ListAsyncResults(ctx context.Context) (ListAsyncResultsResponse, error)
 	ListBulkVolumeJobs(ctx context.Context) (ListBulkVolumeJobsResponse, error)
+	GetClusterInfo(ctx context.Context) (GetClusterInfoResponse, error)
+	ListQoSPolicies(ctx context.Context) (ListQoSPoliciesResponse, error)
+	ListDeletedVolumes(ctx context.Context) (ListDeletedVolumesResponse, error)
+	ListSnapshots(ctx context.Context) (ListSnapshotsResponse, error)
+	ListGroupSnapshots(ctx context.Context) (ListGroupSnapshotsResponse, error)
+	ListSchedules(ctx context.Context) (ListSchedulesResponse, error)
+	ListClusterPairs(ctx context.Context) (ListClusterPairsResponse, error)
+	ListActivePairedVolumes(ctx context.Context) (ListActivePairedVolumesResponse, error)
+	ListSnapMirrorEndpoints(ctx context.Context) (ListSnapMirrorEndpointsResponse, error)
+	ListSnapMirrorRelationships(ctx context.Context) (ListSnapMirrorRelationshipsResponse, error)
+	GetClusterVersionInfo(ctx context.Context) (GetClusterVersionInfoResponse, error)
+	GetClusterMasterNodeID(ctx context.Context) (GetClusterMasterNodeIDResponse, error)
+	GetIpmiInfo(ctx context.Context) (GetIpmiInfoResponse, error)
+	GetNodeHardwareInfo(ctx context.Context, nodeID int) (GetNodeHardwareInfoResponse, error)
+	APIVersion() string
 }
 type RPCBody struct {
 	Method RPC       `json:"method"`
@@ -128,6 +230,10 @@ type ListBulkVolumeJobsParams struct {
 	// No params needed
 }
 
+type GetClusterInfoParams struct {
+	// No params needed
+}
+
 type ListVolumesResponse struct {
 	ID     int `json:"id"`
 	Result struct {
@@ -164,18 +270,18 @@ type ListVolumesResponse struct {
 				MaxIOPS int `json:"maxIOPS"`
 				MinIOPS int `json:"minIOPS"`
 			} `json:"qos"`
-			QosPolicyID                interface{}   `json:"qosPolicyID"`
-			ScsiEUIDeviceID            string        `json:"scsiEUIDeviceID"`
-			ScsiNAADeviceID            string        `json:"scsiNAADeviceID"`
-			SliceCount                 int           `json:"sliceCount"`
-			Status                     string        `json:"status"`
-			TotalSize                  int64         `json:"totalSize"`
-			VirtualVolumeID            interface{}   `json:"virtualVolumeID"`
-			VolumeAccessGroups         []interface{} `json:"volumeAccessGroups"`
-			VolumeConsistencyGroupUUID string        `json:"volumeConsistencyGroupUUID"`
-			VolumeID                   int           `json:"volumeID"`
-			VolumePairs                []interface{} `json:"volumePairs"`
-			VolumeUUID                 string        `json:"volumeUUID"`
+			QosPolicyID                interface{}  `json:"qosPolicyID"`
+			ScsiEUIDeviceID            string       `json:"scsiEUIDeviceID"`
+			ScsiNAADeviceID            string       `json:"scsiNAADeviceID"`
+			SliceCount                 int          `json:"sliceCount"`
+			Status                     string       `json:"status"`
+			TotalSize                  int64        `json:"totalSize"`
+			VirtualVolumeID            interface{}  `json:"virtualVolumeID"`
+			VolumeAccessGroups         []int        `json:"volumeAccessGroups"`
+			VolumeConsistencyGroupUUID string       `json:"volumeConsistencyGroupUUID"`
+			VolumeID                   int          `json:"volumeID"`
+			VolumePairs                []VolumePair `json:"volumePairs"`
+			VolumeUUID                 string       `json:"volumeUUID"`
 		} `json:"volumes"`
 	} `json:"result"`
 }
@@ -628,3 +734,381 @@ type ListAsyncResultsResponse struct {
 		} `json:"asyncHandles"`
 	} `json:"result"`
 }
+
+type GetClusterInfoResponse struct {
+	ID     int `json:"id"`
+	Result struct {
+		ClusterInfo struct {
+			Attributes                    map[string]any `json:"attributes"`
+			DefaultProtectionScheme       string         `json:"defaultProtectionScheme"`
+			EnabledProtectionSchemes      []string       `json:"enabledProtectionSchemes"`
+			EncryptionAtRestState         string         `json:"encryptionAtRestState"`
+			Ensemble                      []string       `json:"ensemble"`
+			MipInterface                  string         `json:"mipInterface"`
+			Mvip                          string         `json:"mvip"`
+			MvipInterface                 string         `json:"mvipInterface"`
+			MvipNodeID                    int            `json:"mvipNodeID"`
+			MvipVlanTag                   string         `json:"mvipVlanTag"`
+			Name                          string         `json:"name"`
+			RepCount                      int            `json:"repCount"`
+			SoftwareEncryptionAtRestState string         `json:"softwareEncryptionAtRestState"`
+			SupportedProtectionSchemes    []string       `json:"supportedProtectionSchemes"`
+			Svip                          string         `json:"svip"`
+			SvipInterface                 string         `json:"svipInterface"`
+			SvipNodeID                    int            `json:"svipNodeID"`
+			SvipVlanTag                   string         `json:"svipVlanTag"`
+			UniqueID                      string         `json:"uniqueID"`
+			UUID                          string         `json:"uuid"`
+		} `json:"clusterInfo"`
+	} `json:"result"`
+}
+
+type GetAPIParams struct {
+	// No params needed
+}
+
+type GetAPIResponse struct {
+	ID     int `json:"id"`
+	Result struct {
+		CurrentVersion    string   `json:"currentVersion"`
+		SupportedVersions []string `json:"supportedVersions"`
+	} `json:"result"`
+}
+
+type GetClusterVersionInfoParams struct {
+	// No params needed
+}
+
+type GetClusterVersionInfoResponse struct {
+	ID     int `json:"id"`
+	Result struct {
+		ClusterAPIVersion  string `json:"clusterAPIVersion"`
+		ClusterVersion     string `json:"clusterVersion"`
+		ClusterVersionInfo []struct {
+			NodeID               int    `json:"nodeID"`
+			NodeInternalRevision string `json:"nodeInternalRevision"`
+			NodeVersion          string `json:"nodeVersion"`
+		} `json:"clusterVersionInfo"`
+		PendingClusterVersion string `json:"pendingClusterVersion"`
+		SoftwareVersionInfo   struct {
+			CurrentVersion string `json:"currentVersion"`
+			NodeID         int    `json:"nodeID"`
+			PackageName    string `json:"packageName"`
+			PendingVersion string `json:"pendingVersion"`
+			StartTime      string `json:"startTime"`
+		} `json:"softwareVersionInfo"`
+	} `json:"result"`
+}
+
+type ListQoSPoliciesParams struct {
+	// No params needed
+}
+
+type ListQoSPoliciesResponse struct {
+	ID     int `json:"id"`
+	Result struct {
+		QoSPolicies []struct {
+			Name        string `json:"name"`
+			QoSPolicyID int    `json:"qosPolicyID"`
+			Qos         struct {
+				BurstIOPS int `json:"burstIOPS"`
+				BurstTime int `json:"burstTime"`
+				MaxIOPS   int `json:"maxIOPS"`
+				MinIOPS   int `json:"minIOPS"`
+			} `json:"qos"`
+			VolumeIDs []int `json:"volumeIDs"`
+		} `json:"qosPolicies"`
+	} `json:"result"`
+}
+
+type ListDeletedVolumesParams struct {
+	// No params needed
+}
+
+type ListDeletedVolumesResponse struct {
+	ID     int `json:"id"`
+	Result struct {
+		Volumes []struct {
+			AccountID          int               `json:"accountID"`
+			Attributes         map[string]string `json:"attributes"`
+			CreateTime         time.Time         `json:"createTime"`
+			DeleteTime         string            `json:"deleteTime"`
+			Name               string            `json:"name"`
+			PurgeTime          string            `json:"purgeTime"`
+			Status             string            `json:"status"`
+			TotalSize          int64             `json:"totalSize"`
+			VolumeAccessGroups []int             `json:"volumeAccessGroups"`
+			VolumeID           int               `json:"volumeID"`
+		} `json:"volumes"`
+	} `json:"result"`
+}
+
+type ListSnapshotsParams struct {
+	// No params needed
+}
+
+type Snapshot struct {
+	Attributes              map[string]string `json:"attributes"`
+	Checksum                string            `json:"checksum"`
+	CreateTime              time.Time         `json:"createTime"`
+	EnableRemoteReplication bool              `json:"enableRemoteReplication"`
+	ExpirationReason        string            `json:"expirationReason"`
+	ExpirationTime          string            `json:"expirationTime"`
+	GroupID                 int               `json:"groupID"`
+	GroupSnapshotUUID       string            `json:"groupSnapshotUUID"`
+	Name                    string            `json:"name"`
+	RemoteStatuses          []struct {
+		RemoteStatus   string `json:"remoteStatus"`
+		VolumePairUUID string `json:"volumePairUUID"`
+	} `json:"remoteStatuses"`
+	SnapshotID   int    `json:"snapshotID"`
+	SnapshotUUID string `json:"snapshotUUID"`
+	Status       string `json:"status"`
+	TotalSize    int64  `json:"totalSize"`
+	VolumeID     int    `json:"volumeID"`
+	VolumeName   string `json:"volumeName"`
+}
+
+type ListSnapshotsResponse struct {
+	ID     int `json:"id"`
+	Result struct {
+		Snapshots []Snapshot `json:"snapshots"`
+	} `json:"result"`
+}
+
+type ListGroupSnapshotsParams struct {
+	// No params needed
+}
+
+type ListGroupSnapshotsResponse struct {
+	ID     int `json:"id"`
+	Result struct {
+		GroupSnapshots []struct {
+			Attributes              map[string]string `json:"attributes"`
+			CreateTime              time.Time         `json:"createTime"`
+			EnableRemoteReplication bool              `json:"enableRemoteReplication"`
+			GroupSnapshotID         int               `json:"groupSnapshotID"`
+			GroupSnapshotUUID       string            `json:"groupSnapshotUUID"`
+			Members                 []Snapshot        `json:"members"`
+			Name                    string            `json:"name"`
+			Status                  string            `json:"status"`
+		} `json:"groupSnapshots"`
+	} `json:"result"`
+}
+
+type ListSchedulesParams struct {
+	// No params needed
+}
+
+type ListSchedulesResponse struct {
+	ID     int `json:"id"`
+	Result struct {
+		Schedules []struct {
+			Attributes struct {
+				Frequency string `json:"frequency"`
+			} `json:"attributes"`
+			HasError           bool   `json:"hasError"`
+			Hours              int    `json:"hours"`
+			LastRunStatus      string `json:"lastRunStatus"`
+			LastRunTimeStarted string `json:"lastRunTimeStarted"`
+			Minutes            int    `json:"minutes"`
+			Monthdays          []int  `json:"monthdays"`
+			Paused             bool   `json:"paused"`
+			Recurring          bool   `json:"recurring"`
+			RunNextInterval    bool   `json:"runNextInterval"`
+			ScheduleID         int    `json:"scheduleID"`
+			ScheduleInfo       struct {
+				EnableRemoteReplication bool   `json:"enableRemoteReplication"`
+				Name                    string `json:"name"`
+				Retention               string `json:"retention"`
+				VolumeID                int    `json:"volumeID"`
+				Volumes                 []int  `json:"volumes"`
+			} `json:"scheduleInfo"`
+			ScheduleName string `json:"scheduleName"`
+			ScheduleType string `json:"scheduleType"`
+			StartingDate string `json:"startingDate"`
+			ToBeDeleted  bool   `json:"toBeDeleted"`
+			Weekdays     []struct {
+				Day    int `json:"day"`
+				Offset int `json:"offset"`
+			} `json:"weekdays"`
+		} `json:"schedules"`
+	} `json:"result"`
+}
+
+type ListClusterPairsParams struct {
+	// No params needed
+}
+
+type ListClusterPairsResponse struct {
+	ID     int `json:"id"`
+	Result struct {
+		ClusterPairs []struct {
+			ClusterName     string `json:"clusterName"`
+			ClusterPairID   int    `json:"clusterPairID"`
+			ClusterPairUUID string `json:"clusterPairUUID"`
+			ClusterUUID     string `json:"clusterUUID"`
+			Latency         int    `json:"latency"`
+			Mvip            string `json:"mvip"`
+			Status          string `json:"status"`
+			Version         string `json:"version"`
+		} `json:"clusterPairs"`
+	} `json:"result"`
+}
+
+type ListActivePairedVolumesParams struct {
+	// No params needed
+}
+
+// VolumePair is the pairing of a volume with a volume on a paired cluster.
+type VolumePair struct {
+	ClusterPairID     int `json:"clusterPairID"`
+	RemoteReplication struct {
+		Mode                string `json:"mode"`
+		PauseLimit          int64  `json:"pauseLimit"`
+		RemoteServiceID     int    `json:"remoteServiceID"`
+		ResumeDetails       string `json:"resumeDetails"`
+		SnapshotReplication struct {
+			State        string `json:"state"`
+			StateDetails string `json:"stateDetails"`
+		} `json:"snapshotReplication"`
+		State        string `json:"state"`
+		StateDetails string `json:"stateDetails"`
+	} `json:"remoteReplication"`
+	RemoteSliceID    int    `json:"remoteSliceID"`
+	RemoteVolumeID   int    `json:"remoteVolumeID"`
+	RemoteVolumeName string `json:"remoteVolumeName"`
+	VolumePairUUID   string `json:"volumePairUUID"`
+}
+
+type ListActivePairedVolumesResponse struct {
+	ID     int `json:"id"`
+	Result struct {
+		Volumes []struct {
+			AccountID   int               `json:"accountID"`
+			Attributes  map[string]string `json:"attributes"`
+			Name        string            `json:"name"`
+			Status      string            `json:"status"`
+			VolumeID    int               `json:"volumeID"`
+			VolumePairs []VolumePair      `json:"volumePairs"`
+		} `json:"volumes"`
+	} `json:"result"`
+}
+
+type ListSnapMirrorEndpointsParams struct {
+	// No params needed
+}
+
+type ListSnapMirrorEndpointsResponse struct {
+	ID     int `json:"id"`
+	Result struct {
+		SnapMirrorEndpoints []struct {
+			ClusterName          string   `json:"clusterName"`
+			IPAddresses          []string `json:"ipAddresses"`
+			IsConnected          bool     `json:"isConnected"`
+			ManagementIP         string   `json:"managementIP"`
+			SnapMirrorEndpointID int      `json:"snapMirrorEndpointID"`
+			Username             string   `json:"username"`
+		} `json:"snapMirrorEndpoints"`
+	} `json:"result"`
+}
+
+type ListSnapMirrorRelationshipsParams struct {
+	// No params needed
+}
+
+// SnapMirrorVolume is the source or destination volume of a SnapMirror
+// relationship, either an Element volume or an ONTAP volume.
+type SnapMirrorVolume struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	VolumeID int    `json:"volumeID"`
+	Vserver  string `json:"vserver"`
+}
+
+type ListSnapMirrorRelationshipsResponse struct {
+	ID     int `json:"id"`
+	Result struct {
+		SnapMirrorRelationships []struct {
+			ClusterName              string           `json:"clusterName"`
+			CurrentMaxTransferRate   int64            `json:"currentMaxTransferRate"`
+			DestinationVolume        SnapMirrorVolume `json:"destinationVolume"`
+			IsHealthy                bool             `json:"isHealthy"`
+			Lagtime                  int64            `json:"lagtime"`
+			LastTransferDuration     int64            `json:"lastTransferDuration"`
+			LastTransferEndTimestamp string           `json:"lastTransferEndTimestamp"`
+			LastTransferError        string           `json:"lastTransferError"`
+			LastTransferSize         int64            `json:"lastTransferSize"`
+			LastTransferType         string           `json:"lastTransferType"`
+			MaxTransferRate          int64            `json:"maxTransferRate"`
+			MirrorState              string           `json:"mirrorState"`
+			NewestSnapshot           string           `json:"newestSnapshot"`
+			PolicyName               string           `json:"policyName"`
+			PolicyType               string           `json:"policyType"`
+			RelationshipStatus       string           `json:"relationshipStatus"`
+			RelationshipType         string           `json:"relationshipType"`
+			ScheduleName             string           `json:"scheduleName"`
+			SnapMirrorEndpointID     int              `json:"snapMirrorEndpointID"`
+			SnapMirrorRelationshipID string           `json:"snapMirrorRelationshipID"`
+			SourceVolume             SnapMirrorVolume `json:"sourceVolume"`
+			UnhealthyReason          string           `json:"unhealthyReason"`
+		} `json:"snapMirrorRelationships"`
+	} `json:"result"`
+}
+
+type GetClusterMasterNodeIDParams struct {
+	// No params needed
+}
+
+type GetClusterMasterNodeIDResponse struct {
+	ID     int `json:"id"`
+	Result struct {
+		NodeID int `json:"nodeID"`
+	} `json:"result"`
+}
+
+type GetIpmiInfoParams struct {
+	// No params needed
+}
+
+type GetIpmiInfoResponse struct {
+	ID     int `json:"id"`
+	Result struct {
+		IpmiInfo struct {
+			SensorReadings []struct {
+				AssertionEvent            string `json:"assertionEvent"`
+				EntityID                  string `json:"entityID"`
+				LowCriticalThreshold      string `json:"lowCriticalThreshold"`
+				LowNonCriticalThreshold   string `json:"lowNonCriticalThreshold"`
+				NodeID                    int    `json:"nodeID"`
+				SensorID                  string `json:"sensorID"`
+				SensorName                string `json:"sensorName"`
+				SensorReading             string `json:"sensorReading"`
+				SensorType                string `json:"sensorType"`
+				SensorUnits               string `json:"sensorUnits"`
+				UniqueSensorID            string `json:"uniqueSensorID"`
+				UpperCriticalThreshold    string `json:"upperCriticalThreshold"`
+				UpperNonCriticalThreshold string `json:"upperNonCriticalThreshold"`
+			} `json:"sensorReadings"`
+		} `json:"ipmiInfo"`
+	} `json:"result"`
+}
+
+type GetNodeHardwareInfoParams struct {
+	NodeID int `json:"nodeID"`
+}
+
+type GetNodeHardwareInfoResponse struct {
+	ID     int `json:"id"`
+	Result struct {
+		NodeHardwareInfo struct {
+			BiosRevision        string `json:"biosRevision"`
+			BiosVendor          string `json:"biosVendor"`
+			BiosVersion         string `json:"biosVersion"`
+			BmcFirmwareRevision string `json:"bmcFirmwareRevision"`
+			BmcIpmiVersion      string `json:"bmcIpmiVersion"`
+			ChassisSerial       string `json:"chassisSerial"`
+			NodeSerial          string `json:"nodeSerial"`
+		} `json:"nodeHardwareInfo"`
+	} `json:"result"`
+}
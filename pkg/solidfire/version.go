@@ -0,0 +1,193 @@
+package solidfire
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/amoghe/distillog"
+)
+
+// SupportedAPIVersions is the compatibility table of Element API versions whose
+// responses decode into the types in this package, oldest first. When the
+// endpoint has no /json-rpc/<version> path, the client picks the highest
+// version in this table that the cluster supports.
+var SupportedAPIVersions = []string{"11.3", "11.5", "11.7", "12.0", "12.2", "12.3", "12.5", "12.7"}
+
+// negotiationAPIVersion is served by every Element release and is used to ask
+// the cluster which versions it supports.
+const negotiationAPIVersion = "1.0"
+
+// negotiationTimeout bounds a negotiation. It runs detached from the context
+// of the call that started it, which may be cancelled before it finishes.
+const negotiationTimeout = 30 * time.Second
+
+var apiVersionPath = regexp.MustCompile(`^/json-rpc/([0-9]+(?:\.[0-9]+)*)/?$`)
+
+// APIVersion returns the API version used by the client: the one in the
+// endpoint path, or the negotiated one. It is empty until negotiation succeeds.
+func (c *Client) APIVersion() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.apiVersion != "" {
+		return c.apiVersion
+	}
+	return versionFromEndpoint(c.RPCEndpoint)
+}
+
+// NegotiateAPIVersion resolves the API version for endpoints given as a bare
+// MVIP. It is called lazily by the first RPC, and can be called at startup to
+// surface negotiation errors early.
+func (c *Client) NegotiateAPIVersion(ctx context.Context) (string, error) {
+	if _, err := c.resolveEndpoint(ctx); err != nil {
+		return "", err
+	}
+	return c.APIVersion(), nil
+}
+
+// negotiation is an API version negotiation in progress. Calls arriving while
+// it runs wait for it and share its result instead of negotiating again.
+type negotiation struct {
+	done chan struct{}
+	err  error
+}
+
+// resolveEndpoint returns the JSON-RPC endpoint to call, negotiating the API
+// version first when RPCEndpoint has no path. c.mu is not held during the
+// negotiation itself, so concurrent calls wait for the one negotiation rather
+// than queueing up for a lock and each negotiating in turn. Every caller,
+// including the one that started the negotiation, stops waiting when its own
+// ctx is done; the negotiation carries on for the others.
+func (c *Client) resolveEndpoint(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	if c.apiVersion != "" {
+		defer c.mu.Unlock()
+		return c.RPCEndpoint, nil
+	}
+	u, err := url.Parse(c.RPCEndpoint)
+	if err != nil {
+		c.mu.Unlock()
+		return "", fmt.Errorf("error parsing RPC Server url: %s", err.Error())
+	}
+	if u.Path != "" && u.Path != "/" {
+		defer c.mu.Unlock()
+		return c.RPCEndpoint, nil
+	}
+	n := c.negotiation
+	if n == nil {
+		n = &negotiation{done: make(chan struct{})}
+		c.negotiation = n
+		go c.runNegotiation(n, u)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-n.done:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	if n.err != nil {
+		return "", n.err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.RPCEndpoint, nil
+}
+
+// runNegotiation negotiates the API version under negotiationTimeout and
+// publishes the result to the callers waiting on n. A failed negotiation is
+// not kept: the next call negotiates again.
+func (c *Client) runNegotiation(n *negotiation, u *url.URL) {
+	ctx, cancel := context.WithTimeout(context.Background(), negotiationTimeout)
+	defer cancel()
+	version, err := c.negotiate(ctx, u)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.negotiation = nil
+	defer close(n.done)
+	if err != nil {
+		n.err = fmt.Errorf("Error negotiating API version with %v: %v", u.Host, err)
+		return
+	}
+	u.Path = "/json-rpc/" + version
+	c.RPCEndpoint = u.String()
+	c.apiVersion = version
+	log.Infof("Negotiated API version %v with %v", version, u.Host)
+}
+
+// negotiate asks the cluster for its supported API versions with GetAPI,
+// falling back to GetClusterVersionInfo, and picks one from
+// SupportedAPIVersions.
+func (c *Client) negotiate(ctx context.Context, u *url.URL) (string, error) {
+	base := *u
+	base.Path = "/json-rpc/" + negotiationAPIVersion
+	endpoint := base.String()
+
+	api := GetAPIResponse{}
+	err := c.call(ctx, endpoint, RPCGetAPI, GetAPIParams{}, &api)
+	if err == nil {
+		return PickAPIVersion(api.Result.SupportedVersions)
+	}
+	info := GetClusterVersionInfoResponse{}
+	if infoErr := c.call(ctx, endpoint, RPCGetClusterVersionInfo, GetClusterVersionInfoParams{}, &info); infoErr != nil {
+		return "", err
+	}
+	var supported []string
+	for _, v := range SupportedAPIVersions {
+		if compareVersions(v, info.Result.ClusterAPIVersion) <= 0 {
+			supported = append(supported, v)
+		}
+	}
+	return PickAPIVersion(supported)
+}
+
+// PickAPIVersion returns the highest version from SupportedAPIVersions that is
+// also in clusterVersions.
+func PickAPIVersion(clusterVersions []string) (string, error) {
+	for i := len(SupportedAPIVersions) - 1; i >= 0; i-- {
+		for _, v := range clusterVersions {
+			if compareVersions(SupportedAPIVersions[i], v) == 0 {
+				return SupportedAPIVersions[i], nil
+			}
+		}
+	}
+	return "", fmt.Errorf("none of the cluster API versions %v are supported, need one of %v", clusterVersions, SupportedAPIVersions)
+}
+
+func versionFromEndpoint(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return ""
+	}
+	m := apiVersionPath.FindStringSubmatch(u.Path)
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
+
+// compareVersions compares dotted numeric versions such as "12.3".
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
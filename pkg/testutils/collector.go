@@ -20,9 +20,13 @@ solidfire_cluster_client_queue_depth 0
 solidfire_cluster_compression_factor 2.094133784391091
 solidfire_cluster_current_iops 0
 solidfire_cluster_de_duplication_factor 1.0000545044935927
+solidfire_cluster_deleted_volume_bytes 1.073741824e+09
+solidfire_cluster_deleted_volume_count 1
 solidfire_cluster_efficiency_factor 18.580522988214764
+solidfire_cluster_ensemble_size 1
 solidfire_cluster_fullness{level="blockFullness"} 0
 solidfire_cluster_fullness{level="metadataFullness"} 0
+solidfire_cluster_info{cluster_name="sfcluster01",encryption_at_rest_state="disabled",ensemble="10.0.0.91",mvip="10.0.0.90",replica_count="2",software_encryption_at_rest_state="enabled",svip="10.0.1.90",unique_id="1mhp",uuid="7c8bbb67-cf7d-4dd1-8a63-a16cd2e1d2d3"} 1
 solidfire_cluster_initiator_count 1
 solidfire_cluster_iops 0
 solidfire_cluster_iops_total 2.4181537e+07
@@ -31,6 +35,7 @@ solidfire_cluster_last_sample_read_ops 0
 solidfire_cluster_last_sample_write_bytes 0
 solidfire_cluster_last_sample_write_ops 0
 solidfire_cluster_latency_seconds 0
+solidfire_cluster_master_changes_total 0
 solidfire_cluster_max_async_result_id 47
 solidfire_cluster_max_iops 3000
 solidfire_cluster_max_metadata_over_provision_factor 5
@@ -43,8 +48,13 @@ solidfire_cluster_metadata_fullness{level="stage2Aware"} 0
 solidfire_cluster_metadata_fullness{level="stage3Low"} 0
 solidfire_cluster_metadata_fullness{level="stage4Critical"} 0
 solidfire_cluster_metadata_fullness{level="stage5CompletelyConsumed"} 0
+solidfire_cluster_node_versions 1
 solidfire_cluster_non_zero_blocks 165133
 solidfire_cluster_normalized_iops 0
+solidfire_cluster_pair_latency_seconds{cluster_name="dr-cluster",cluster_pair_id="1"} 0.002
+solidfire_cluster_pair_status{cluster_name="dr-cluster",cluster_pair_id="1",status="Connected"} 1
+solidfire_cluster_pair_status{cluster_name="dr-cluster",cluster_pair_id="1",status="Disconnected"} 0
+solidfire_cluster_pair_status{cluster_name="dr-cluster",cluster_pair_id="1",status="Misconfigured"} 0
 solidfire_cluster_peak_active_sessions 1
 solidfire_cluster_peak_iops 6
 solidfire_cluster_provisioned_space_bytes 6.001000448e+09
@@ -74,11 +84,13 @@ solidfire_cluster_unaligned_reads_total 13
 solidfire_cluster_unaligned_writes_total 0
 solidfire_cluster_unique_blocks 165124
 solidfire_cluster_unique_blocks_used_space_bytes 3.47282402e+08
+solidfire_cluster_upgrade_pending 0
 solidfire_cluster_used_bytes 3.47282402e+08
 solidfire_cluster_used_metadata_bytes 7.221248e+06
 solidfire_cluster_used_metadata_space_bytes 7.221248e+06
 solidfire_cluster_used_metadata_space_in_snapshots_bytes 7.221248e+06
 solidfire_cluster_used_space_bytes 3.47282402e+08
+solidfire_cluster_version_info{cluster_api_version="12.3",cluster_version="12.3.0.958",pending_cluster_version="12.3.0.958"} 1
 solidfire_cluster_volume_access_group_count 1
 solidfire_cluster_volume_async_result_active{type="BulkVolume"} 0
 solidfire_cluster_volume_async_result_active{type="Clone"} 0
@@ -122,8 +134,16 @@ solidfire_drive_status{drive_id="4",node_id="1",node_name="n01",serial="sde",slo
 solidfire_drive_status{drive_id="4",node_id="1",node_name="n01",serial="sde",slot="4",status="erasing",type="block"} 0
 solidfire_drive_status{drive_id="4",node_id="1",node_name="n01",serial="sde",slot="4",status="failed",type="block"} 0
 solidfire_drive_status{drive_id="4",node_id="1",node_name="n01",serial="sde",slot="4",status="removing",type="block"} 0
+solidfire_inventory_changes_total{change="added",kind="node"} 0
+solidfire_inventory_changes_total{change="added",kind="volume"} 0
+solidfire_inventory_changes_total{change="removed",kind="node"} 0
+solidfire_inventory_changes_total{change="removed",kind="volume"} 0
+solidfire_inventory_objects{kind="node"} 1
+solidfire_inventory_objects{kind="volume"} 2
+solidfire_node_cluster_master{node_id="1",node_name="n01"} 1
 solidfire_node_cpu_percentage{node_id="1",node_name="n01"} 0
 solidfire_node_cpu_seconds_total{node_id="1",node_name="n01"} 2247
+solidfire_node_ensemble_member{node_id="1",node_name="n01"} 1
 solidfire_node_info{associated_fservice_id="0",associated_master_service_id="1",chassis_name="",chassis_type="SFVIRT",cpu_model="Intel(R) Xeon(R) CPU E7-8891 v4 @ 2.80GHz\nUnknown Processor",node_id="1",node_name="n01",node_type="SFDEMO-NE",platform_config_version="0.0.0.0",sip="10.0.0.91",sipi="eth1",software_version="11.7.0.76",uuid="5329FE1F-A41F-DC41-8BD9-2016FF2DD8FF"} 1
 solidfire_node_interface_in_bytes_total{interface="cluster",node_id="1",node_name="n01"} 282366
 solidfire_node_interface_in_bytes_total{interface="management",node_id="1",node_name="n01"} 332883
@@ -147,7 +167,45 @@ solidfire_node_read_latency_seconds_total{node_id="1",node_name="n01"} 0
 solidfire_node_samples{node_id="1",node_name="n01"} 294
 solidfire_node_total_memory_bytes{node_id="1",node_name="n01"} 1.6e+10
 solidfire_node_used_memory_bytes{node_id="1",node_name="n01"} 9.000198144e+09
+solidfire_node_version_info{node_id="1",node_name="n01",node_version="12.3.0.958"} 1
 solidfire_node_write_latency_seconds_total{node_id="1",node_name="n01"} 0
+solidfire_exporter_api_version_info{api_version="11.3"} 1
+solidfire_schedule_last_run_success{schedule_id="1",schedule_name="daily-snap"} 1
+solidfire_schedule_last_run_success{schedule_id="2",schedule_name="hourly-snap"} 0
+solidfire_schedule_last_run_timestamp_seconds{schedule_id="1",schedule_name="daily-snap"} 1.618538403e+09
+solidfire_schedule_last_run_timestamp_seconds{schedule_id="2",schedule_name="hourly-snap"} 1.6185456e+09
+solidfire_schedule_next_run_timestamp_seconds{schedule_id="1",schedule_name="daily-snap"} 1.6187976e+09
+solidfire_schedule_next_run_timestamp_seconds{schedule_id="3",schedule_name="monthly-snap"} 1.6184466e+09
+solidfire_schedule_paused{schedule_id="1",schedule_name="daily-snap"} 0
+solidfire_schedule_paused{schedule_id="2",schedule_name="hourly-snap"} 1
+solidfire_schedule_paused{schedule_id="3",schedule_name="monthly-snap"} 0
+solidfire_schedule_volumes{schedule_id="1",schedule_name="daily-snap"} 2
+solidfire_schedule_volumes{schedule_id="2",schedule_name="hourly-snap"} 1
+solidfire_schedule_volumes{schedule_id="3",schedule_name="monthly-snap"} 1
+solidfire_scrape_collector_success{collector="accounts"} 1
+solidfire_scrape_collector_success{collector="async_results"} 1
+solidfire_scrape_collector_success{collector="bulk_volume_jobs"} 1
+solidfire_scrape_collector_success{collector="cluster_capacity"} 1
+solidfire_scrape_collector_success{collector="cluster_full_threshold"} 1
+solidfire_scrape_collector_success{collector="cluster_info"} 1
+solidfire_scrape_collector_success{collector="cluster_stats"} 1
+solidfire_scrape_collector_success{collector="deleted_volumes"} 1
+solidfire_scrape_collector_success{collector="drives"} 1
+solidfire_scrape_collector_success{collector="ensemble"} 1
+solidfire_scrape_collector_success{collector="faults"} 1
+solidfire_scrape_collector_success{collector="initiators"} 1
+solidfire_scrape_collector_success{collector="iscsi"} 1
+solidfire_scrape_collector_success{collector="node_meta"} 1
+solidfire_scrape_collector_success{collector="node_stats"} 1
+solidfire_scrape_collector_success{collector="qos_histograms"} 1
+solidfire_scrape_collector_success{collector="replication"} 1
+solidfire_scrape_collector_success{collector="schedules"} 1
+solidfire_scrape_collector_success{collector="virtual_volume_tasks"} 1
+solidfire_scrape_collector_success{collector="volume_access_groups"} 1
+solidfire_scrape_collector_success{collector="volume_meta"} 1
+solidfire_scrape_collector_success{collector="volume_qos"} 1
+solidfire_scrape_collector_success{collector="volume_state"} 1
+solidfire_scrape_collector_success{collector="volume_stats"} 1
 solidfire_up 1
 solidfire_volume_actual_iops{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 0
 solidfire_volume_actual_iops{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 0
@@ -159,10 +217,22 @@ solidfire_volume_client_queue_depth{account_id="test_owner",volume_id="1",volume
 solidfire_volume_client_queue_depth{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 0
 solidfire_cluster_volume_count{status="active"} 2
 solidfire_cluster_volume_virtual_volume_task_count 1
+solidfire_volume_created_timestamp_seconds{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 1.615264843e+09
+solidfire_volume_created_timestamp_seconds{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 1.618162188e+09
+solidfire_volume_info{access="readWrite",account_name="jamesw",attributes="owner_id=test_owner",block_size="4096",enable512e="true",iqn="iqn.2010-01.com.solidfire:1mhp.test-volume1.1",protection_scheme="singleHelix",scsi_eui_device_id="316d687000000001f47acc0100000000",scsi_naa_device_id="6f47acc100000000316d687000000001",slice_count="1",status="active",volume_access_groups="1",volume_account_id="1",volume_consistency_group_uuid="8918cf90-ccfc-4188-8438-744f891aa4fe",volume_id="1",volume_name="test-volume1",volume_uuid="763a0938-6906-4006-af96-ed42a3ea807e"} 1
+solidfire_volume_info{access="readWrite",account_name="jamesw",attributes="owner_id=test_owner",block_size="4096",enable512e="true",iqn="iqn.2010-01.com.solidfire:1mhp.test-volume2.2",protection_scheme="singleHelix",scsi_eui_device_id="316d687000000002f47acc0100000000",scsi_naa_device_id="6f47acc100000000316d687000000002",slice_count="1",status="active",volume_access_groups="",volume_account_id="1",volume_consistency_group_uuid="b20d084c-68c9-4945-a891-bd4fa0318f16",volume_id="2",volume_name="test-volume2",volume_uuid="6826da37-cce7-41a0-9102-6bc2462b0f85"} 1
+solidfire_volume_last_io_timestamp_seconds{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 1.618547424e+09
 solidfire_volume_latency_seconds{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 0
 solidfire_volume_latency_seconds{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 0
 solidfire_volume_non_zero_blocks{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 165133
 solidfire_volume_non_zero_blocks{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 0
+solidfire_volume_pair_info{account_id="test_owner",cluster_pair_id="1",mode="Async",remote_volume_id="8",remote_volume_name="test-volume1-dr",volume_id="1",volume_name="test-volume1"} 1
+solidfire_volume_pair_last_replicated_snapshot_timestamp_seconds{account_id="test_owner",cluster_pair_id="1",volume_id="1",volume_name="test-volume1"} 1.6172352e+09
+solidfire_volume_pair_snapshot_replication_state{account_id="test_owner",cluster_pair_id="1",state="Idle",volume_id="1",volume_name="test-volume1"} 1
+solidfire_volume_pair_state{account_id="test_owner",cluster_pair_id="1",state="Active",volume_id="1",volume_name="test-volume1"} 1
+solidfire_volume_provisioned_bytes{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 2.000683008e+09
+solidfire_volume_provisioned_bytes{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 4.00031744e+09
+solidfire_volume_purge_timestamp_seconds{account_id="test_owner",volume_id="3",volume_name="test-volume3"} 1.618896624e+09
 solidfire_volume_qos_below_min_iops_percentage_bucket{account_id="test_owner",volume_id="1",volume_name="test-volume1",le="19"} 32
 solidfire_volume_qos_below_min_iops_percentage_bucket{account_id="test_owner",volume_id="1",volume_name="test-volume1",le="39"} 6
 solidfire_volume_qos_below_min_iops_percentage_bucket{account_id="test_owner",volume_id="1",volume_name="test-volume1",le="59"} 4
@@ -179,6 +249,32 @@ solidfire_volume_qos_below_min_iops_percentage_bucket{account_id="test_owner",vo
 solidfire_volume_qos_below_min_iops_percentage_bucket{account_id="test_owner",volume_id="2",volume_name="test-volume2",le="+Inf"} 0
 solidfire_volume_qos_below_min_iops_percentage_sum{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 0
 solidfire_volume_qos_below_min_iops_percentage_count{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 0
+solidfire_volume_qos_burst_iops{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 15000
+solidfire_volume_qos_burst_iops{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 15000
+solidfire_volume_qos_burst_time_seconds{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 60
+solidfire_volume_qos_burst_time_seconds{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 60
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="1048576",volume_id="1",volume_name="test-volume1"} 15000
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="1048576",volume_id="2",volume_name="test-volume2"} 15000
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="131072",volume_id="1",volume_name="test-volume1"} 1950
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="131072",volume_id="2",volume_name="test-volume2"} 1950
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="16384",volume_id="1",volume_name="test-volume1"} 270
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="16384",volume_id="2",volume_name="test-volume2"} 270
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="262144",volume_id="1",volume_name="test-volume1"} 3900
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="262144",volume_id="2",volume_name="test-volume2"} 3900
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="32768",volume_id="1",volume_name="test-volume1"} 500
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="32768",volume_id="2",volume_name="test-volume2"} 500
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="4096",volume_id="1",volume_name="test-volume1"} 100
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="4096",volume_id="2",volume_name="test-volume2"} 100
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="524288",volume_id="1",volume_name="test-volume1"} 7600
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="524288",volume_id="2",volume_name="test-volume2"} 7600
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="65536",volume_id="1",volume_name="test-volume1"} 1000
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="65536",volume_id="2",volume_name="test-volume2"} 1000
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="8192",volume_id="1",volume_name="test-volume1"} 160
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="8192",volume_id="2",volume_name="test-volume2"} 160
+solidfire_volume_qos_max_iops{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 5000
+solidfire_volume_qos_max_iops{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 5000
+solidfire_volume_qos_min_iops{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 50
+solidfire_volume_qos_min_iops{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 50
 solidfire_volume_qos_min_to_max_iops_percentage_bucket{account_id="test_owner",volume_id="1",volume_name="test-volume1",le="19"} 167
 solidfire_volume_qos_min_to_max_iops_percentage_bucket{account_id="test_owner",volume_id="1",volume_name="test-volume1",le="39"} 3823
 solidfire_volume_qos_min_to_max_iops_percentage_bucket{account_id="test_owner",volume_id="1",volume_name="test-volume1",le="59"} 2304
@@ -195,6 +291,7 @@ solidfire_volume_qos_min_to_max_iops_percentage_bucket{account_id="test_owner",v
 solidfire_volume_qos_min_to_max_iops_percentage_bucket{account_id="test_owner",volume_id="2",volume_name="test-volume2",le="+Inf"} 0
 solidfire_volume_qos_min_to_max_iops_percentage_sum{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 0
 solidfire_volume_qos_min_to_max_iops_percentage_count{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 0
+solidfire_volume_qos_policy_info{account_id="test_owner",qos_policy_id="1",qos_policy_name="gold",volume_id="1",volume_name="test-volume1"} 1
 solidfire_volume_qos_read_block_sizes_bytes_bucket{account_id="test_owner",volume_id="1",volume_name="test-volume1",le="8191"} 1.1091915e+07
 solidfire_volume_qos_read_block_sizes_bytes_bucket{account_id="test_owner",volume_id="1",volume_name="test-volume1",le="16383"} 27
 solidfire_volume_qos_read_block_sizes_bytes_bucket{account_id="test_owner",volume_id="1",volume_name="test-volume1",le="32767"} 78
@@ -271,6 +368,18 @@ solidfire_volume_read_ops_total{account_id="test_owner",volume_id="1",volume_nam
 solidfire_volume_read_ops_total{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 0
 solidfire_volume_size_bytes{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 2.000683008e+09
 solidfire_volume_size_bytes{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 4.00031744e+09
+solidfire_volume_state{account_id="test_owner",state="active",volume_id="1",volume_name="test-volume1"} 0
+solidfire_volume_state{account_id="test_owner",state="active",volume_id="2",volume_name="test-volume2"} 0
+solidfire_volume_state{account_id="test_owner",state="active",volume_id="3",volume_name="test-volume3"} 0
+solidfire_volume_state{account_id="test_owner",state="deleted",volume_id="1",volume_name="test-volume1"} 0
+solidfire_volume_state{account_id="test_owner",state="deleted",volume_id="2",volume_name="test-volume2"} 0
+solidfire_volume_state{account_id="test_owner",state="deleted",volume_id="3",volume_name="test-volume3"} 1
+solidfire_volume_state{account_id="test_owner",state="idle",volume_id="1",volume_name="test-volume1"} 1
+solidfire_volume_state{account_id="test_owner",state="idle",volume_id="2",volume_name="test-volume2"} 0
+solidfire_volume_state{account_id="test_owner",state="idle",volume_id="3",volume_name="test-volume3"} 0
+solidfire_volume_state{account_id="test_owner",state="unmapped",volume_id="1",volume_name="test-volume1"} 0
+solidfire_volume_state{account_id="test_owner",state="unmapped",volume_id="2",volume_name="test-volume2"} 1
+solidfire_volume_state{account_id="test_owner",state="unmapped",volume_id="3",volume_name="test-volume3"} 0
 solidfire_volume_throttle{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 0
 solidfire_volume_throttle{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 0
 solidfire_volume_unaligned_reads_total{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 13
@@ -290,9 +399,347 @@ solidfire_volume_zero_blocks{account_id="test_owner",volume_id="2",volume_name="
 `), "\n")
 
 var CollectOutputVolumeListErr = strings.Split(strings.TrimSpace(`
+solidfire_cluster_account_count 2
+solidfire_cluster_active_block_space_bytes 4.977419581e+09
+solidfire_cluster_active_faults{code="driveAvailable",details="Node ID 1 has 1 available drive(s).",drive_id="0.000000",node_hardware_fault_id="0.000000",node_id="1",node_name="n01",resolved="false",service_id="0.000000",severity="warning",type="drive"} 1
+solidfire_cluster_active_sessions 1
+solidfire_cluster_average_io_bytes 0
+solidfire_cluster_average_iops 0
+solidfire_cluster_block_fullness{level="stage1Happy"} 0
+solidfire_cluster_block_fullness{level="stage2Aware"} 1
+solidfire_cluster_block_fullness{level="stage3Low"} 0
+solidfire_cluster_block_fullness{level="stage4Critical"} 0
+solidfire_cluster_block_fullness{level="stage5CompletelyConsumed"} 0
+solidfire_cluster_client_queue_depth 0
+solidfire_cluster_compression_factor 2.094133784391091
+solidfire_cluster_current_iops 0
+solidfire_cluster_de_duplication_factor 1.0000545044935927
+solidfire_cluster_deleted_volume_bytes 1.073741824e+09
+solidfire_cluster_deleted_volume_count 1
+solidfire_cluster_efficiency_factor 18.580522988214764
+solidfire_cluster_ensemble_size 1
+solidfire_cluster_fullness{level="blockFullness"} 0
+solidfire_cluster_fullness{level="metadataFullness"} 0
+solidfire_cluster_info{cluster_name="sfcluster01",encryption_at_rest_state="disabled",ensemble="10.0.0.91",mvip="10.0.0.90",replica_count="2",software_encryption_at_rest_state="enabled",svip="10.0.1.90",unique_id="1mhp",uuid="7c8bbb67-cf7d-4dd1-8a63-a16cd2e1d2d3"} 1
+solidfire_cluster_initiator_count 1
+solidfire_cluster_iops 0
+solidfire_cluster_iops_total 2.4181537e+07
+solidfire_cluster_last_sample_read_bytes 0
+solidfire_cluster_last_sample_read_ops 0
+solidfire_cluster_last_sample_write_bytes 0
+solidfire_cluster_last_sample_write_ops 0
+solidfire_cluster_latency_seconds 0
+solidfire_cluster_master_changes_total 0
+solidfire_cluster_max_async_result_id 47
+solidfire_cluster_max_iops 3000
+solidfire_cluster_max_metadata_over_provision_factor 5
+solidfire_cluster_max_over_provisionable_space_bytes 1.855425871872e+13
+solidfire_cluster_max_provisioned_space_bytes 3.710851743744e+12
+solidfire_cluster_max_used_metadata_space_bytes 1.4495514624e+10
+solidfire_cluster_max_used_space_bytes 1.073741824e+11
+solidfire_cluster_metadata_fullness{level="stage1Happy"} 1
+solidfire_cluster_metadata_fullness{level="stage2Aware"} 0
+solidfire_cluster_metadata_fullness{level="stage3Low"} 0
+solidfire_cluster_metadata_fullness{level="stage4Critical"} 0
+solidfire_cluster_metadata_fullness{level="stage5CompletelyConsumed"} 0
+solidfire_cluster_node_versions 1
+solidfire_cluster_non_zero_blocks 165133
+solidfire_cluster_normalized_iops 0
+solidfire_cluster_pair_latency_seconds{cluster_name="dr-cluster",cluster_pair_id="1"} 0.002
+solidfire_cluster_pair_status{cluster_name="dr-cluster",cluster_pair_id="1",status="Connected"} 1
+solidfire_cluster_pair_status{cluster_name="dr-cluster",cluster_pair_id="1",status="Disconnected"} 0
+solidfire_cluster_pair_status{cluster_name="dr-cluster",cluster_pair_id="1",status="Misconfigured"} 0
+solidfire_cluster_peak_active_sessions 1
+solidfire_cluster_peak_iops 6
+solidfire_cluster_provisioned_space_bytes 6.001000448e+09
+solidfire_cluster_read_bytes_total 4.5445102592e+10
+solidfire_cluster_read_latency_seconds 0
+solidfire_cluster_read_latency_seconds_total 0
+solidfire_cluster_read_ops_total 1.109215e+07
+solidfire_cluster_recent_io_size_bytes 0
+solidfire_cluster_sample_period_seconds 0.5
+solidfire_cluster_services_expected 1
+solidfire_cluster_services_running 1
+solidfire_cluster_slice_reserve_used_threshold_percentage 5
+solidfire_cluster_snapshot_non_zero_blocks 0
+solidfire_cluster_stage2_aware_threshold_percentage 3
+solidfire_cluster_stage2_block_threshold_bytes 0
+solidfire_cluster_stage3_block_threshold_bytes 9.8784247808e+10
+solidfire_cluster_stage3_block_threshold_percentage 3
+solidfire_cluster_stage3_low_threshold_percentage 2
+solidfire_cluster_stage4_block_threshold_bytes 1.0200547328e+11
+solidfire_cluster_stage4_critical_threshold_percentage 1
+solidfire_cluster_stage5_block_threshold_bytes 1.073741824e+11
+solidfire_cluster_thin_provisioning_factor 8.872169705631219
+solidfire_cluster_throughput_utilization 0
+solidfire_cluster_total_bytes 1.073741824e+11
+solidfire_cluster_total_metadata_bytes 1.4495514624e+10
+solidfire_cluster_unaligned_reads_total 13
+solidfire_cluster_unaligned_writes_total 0
+solidfire_cluster_unique_blocks 165124
+solidfire_cluster_unique_blocks_used_space_bytes 3.47282402e+08
+solidfire_cluster_upgrade_pending 0
+solidfire_cluster_used_bytes 3.47282402e+08
+solidfire_cluster_used_metadata_bytes 7.221248e+06
+solidfire_cluster_used_metadata_space_bytes 7.221248e+06
+solidfire_cluster_used_metadata_space_in_snapshots_bytes 7.221248e+06
+solidfire_cluster_used_space_bytes 3.47282402e+08
+solidfire_cluster_version_info{cluster_api_version="12.3",cluster_version="12.3.0.958",pending_cluster_version="12.3.0.958"} 1
+solidfire_cluster_volume_access_group_count 1
+solidfire_cluster_volume_async_result_active{type="BulkVolume"} 0
+solidfire_cluster_volume_async_result_active{type="Clone"} 0
+solidfire_cluster_volume_async_result_active{type="DriveAdd"} 0
+solidfire_cluster_volume_async_result_active{type="DriveRemoval"} 0
+solidfire_cluster_volume_async_result_active{type="NotClone"} 1
+solidfire_cluster_volume_async_result_active{type="RtfiPendingNode"} 0
+solidfire_cluster_volume_async_result{type="BulkVolume"} 0
+solidfire_cluster_volume_async_result{type="Clone"} 5
+solidfire_cluster_volume_async_result{type="DriveAdd"} 0
+solidfire_cluster_volume_async_result{type="DriveRemoval"} 0
+solidfire_cluster_volume_async_result{type="NotClone"} 2
+solidfire_cluster_volume_async_result{type="RtfiPendingNode"} 0
+solidfire_cluster_volume_bulk_volume_job_count 1
+solidfire_cluster_volume_virtual_volume_task_count 1
+solidfire_cluster_write_bytes_total 1.21720639488e+11
+solidfire_cluster_write_latency_seconds 0
+solidfire_cluster_write_latency_seconds_total 0
+solidfire_cluster_write_ops_total 1.3089387e+07
+solidfire_cluster_zero_blocks 1.299955e+06
+solidfire_drive_capacity_bytes{drive_id="1",node_id="1",node_name="n01",serial="sdb",slot="1",type="volume"} 3.221225472e+10
+solidfire_drive_capacity_bytes{drive_id="2",node_id="1",node_name="n01",serial="sdc",slot="2",type="block"} 5.36870912e+10
+solidfire_drive_capacity_bytes{drive_id="3",node_id="1",node_name="n01",serial="sdd",slot="3",type="block"} 5.36870912e+10
+solidfire_drive_capacity_bytes{drive_id="4",node_id="1",node_name="n01",serial="sde",slot="4",type="block"} 5.36870912e+10
+solidfire_drive_status{drive_id="1",node_id="1",node_name="n01",serial="sdb",slot="1",status="active",type="volume"} 1
+solidfire_drive_status{drive_id="1",node_id="1",node_name="n01",serial="sdb",slot="1",status="available",type="volume"} 0
+solidfire_drive_status{drive_id="1",node_id="1",node_name="n01",serial="sdb",slot="1",status="erasing",type="volume"} 0
+solidfire_drive_status{drive_id="1",node_id="1",node_name="n01",serial="sdb",slot="1",status="failed",type="volume"} 0
+solidfire_drive_status{drive_id="1",node_id="1",node_name="n01",serial="sdb",slot="1",status="removing",type="volume"} 0
+solidfire_drive_status{drive_id="2",node_id="1",node_name="n01",serial="sdc",slot="2",status="active",type="block"} 0
+solidfire_drive_status{drive_id="2",node_id="1",node_name="n01",serial="sdc",slot="2",status="available",type="block"} 1
+solidfire_drive_status{drive_id="2",node_id="1",node_name="n01",serial="sdc",slot="2",status="erasing",type="block"} 0
+solidfire_drive_status{drive_id="2",node_id="1",node_name="n01",serial="sdc",slot="2",status="failed",type="block"} 0
+solidfire_drive_status{drive_id="2",node_id="1",node_name="n01",serial="sdc",slot="2",status="removing",type="block"} 0
+solidfire_drive_status{drive_id="3",node_id="1",node_name="n01",serial="sdd",slot="3",status="active",type="block"} 1
+solidfire_drive_status{drive_id="3",node_id="1",node_name="n01",serial="sdd",slot="3",status="available",type="block"} 0
+solidfire_drive_status{drive_id="3",node_id="1",node_name="n01",serial="sdd",slot="3",status="erasing",type="block"} 0
+solidfire_drive_status{drive_id="3",node_id="1",node_name="n01",serial="sdd",slot="3",status="failed",type="block"} 0
+solidfire_drive_status{drive_id="3",node_id="1",node_name="n01",serial="sdd",slot="3",status="removing",type="block"} 0
+solidfire_drive_status{drive_id="4",node_id="1",node_name="n01",serial="sde",slot="4",status="active",type="block"} 1
+solidfire_drive_status{drive_id="4",node_id="1",node_name="n01",serial="sde",slot="4",status="available",type="block"} 0
+solidfire_drive_status{drive_id="4",node_id="1",node_name="n01",serial="sde",slot="4",status="erasing",type="block"} 0
+solidfire_drive_status{drive_id="4",node_id="1",node_name="n01",serial="sde",slot="4",status="failed",type="block"} 0
+solidfire_drive_status{drive_id="4",node_id="1",node_name="n01",serial="sde",slot="4",status="removing",type="block"} 0
+solidfire_exporter_api_version_info{api_version="11.3"} 1
+solidfire_inventory_changes_total{change="added",kind="node"} 0
+solidfire_inventory_changes_total{change="removed",kind="node"} 0
+solidfire_inventory_objects{kind="node"} 1
+solidfire_node_cluster_master{node_id="1",node_name="n01"} 1
+solidfire_node_cpu_percentage{node_id="1",node_name="n01"} 0
+solidfire_node_cpu_seconds_total{node_id="1",node_name="n01"} 2247
+solidfire_node_ensemble_member{node_id="1",node_name="n01"} 1
 solidfire_node_info{associated_fservice_id="0",associated_master_service_id="1",chassis_name="",chassis_type="SFVIRT",cpu_model="Intel(R) Xeon(R) CPU E7-8891 v4 @ 2.80GHz\nUnknown Processor",node_id="1",node_name="n01",node_type="SFDEMO-NE",platform_config_version="0.0.0.0",sip="10.0.0.91",sipi="eth1",software_version="11.7.0.76",uuid="5329FE1F-A41F-DC41-8BD9-2016FF2DD8FF"} 1
+solidfire_node_interface_in_bytes_total{interface="cluster",node_id="1",node_name="n01"} 282366
+solidfire_node_interface_in_bytes_total{interface="management",node_id="1",node_name="n01"} 332883
+solidfire_node_interface_in_bytes_total{interface="storage",node_id="1",node_name="n01"} 282366
+solidfire_node_interface_out_bytes_total{interface="cluster",node_id="1",node_name="n01"} 59773
+solidfire_node_interface_out_bytes_total{interface="management",node_id="1",node_name="n01"} 130104
+solidfire_node_interface_out_bytes_total{interface="storage",node_id="1",node_name="n01"} 59773
+solidfire_node_interface_utilization_percentage{interface="cluster",node_id="1",node_name="n01"} 0
+solidfire_node_interface_utilization_percentage{interface="storage",node_id="1",node_name="n01"} 0
+solidfire_node_iscsi_sessions{node_id="1",node_name="n01"} 1
+solidfire_node_load_bucket{node_id="1",node_name="n01",le="+Inf"} 294
+solidfire_node_load_bucket{node_id="1",node_name="n01",le="0"} 1.205996e+06
+solidfire_node_load_bucket{node_id="1",node_name="n01",le="100"} 0
+solidfire_node_load_bucket{node_id="1",node_name="n01",le="19"} 4.606744e+06
+solidfire_node_load_bucket{node_id="1",node_name="n01",le="39"} 1.192471e+06
+solidfire_node_load_bucket{node_id="1",node_name="n01",le="59"} 89
+solidfire_node_load_bucket{node_id="1",node_name="n01",le="79"} 0
+solidfire_node_load_count{node_id="1",node_name="n01"} 294
+solidfire_node_load_sum{node_id="1",node_name="n01"} 7.0053e+06
+solidfire_node_read_latency_seconds_total{node_id="1",node_name="n01"} 0
+solidfire_node_samples{node_id="1",node_name="n01"} 294
 solidfire_node_total_memory_bytes{node_id="1",node_name="n01"} 1.6e+10
-solidfire_up 0
+solidfire_node_used_memory_bytes{node_id="1",node_name="n01"} 9.000198144e+09
+solidfire_node_version_info{node_id="1",node_name="n01",node_version="12.3.0.958"} 1
+solidfire_node_write_latency_seconds_total{node_id="1",node_name="n01"} 0
+solidfire_schedule_last_run_success{schedule_id="1",schedule_name="daily-snap"} 1
+solidfire_schedule_last_run_success{schedule_id="2",schedule_name="hourly-snap"} 0
+solidfire_schedule_last_run_timestamp_seconds{schedule_id="1",schedule_name="daily-snap"} 1.618538403e+09
+solidfire_schedule_last_run_timestamp_seconds{schedule_id="2",schedule_name="hourly-snap"} 1.6185456e+09
+solidfire_schedule_next_run_timestamp_seconds{schedule_id="1",schedule_name="daily-snap"} 1.6187976e+09
+solidfire_schedule_next_run_timestamp_seconds{schedule_id="3",schedule_name="monthly-snap"} 1.6184466e+09
+solidfire_schedule_paused{schedule_id="1",schedule_name="daily-snap"} 0
+solidfire_schedule_paused{schedule_id="2",schedule_name="hourly-snap"} 1
+solidfire_schedule_paused{schedule_id="3",schedule_name="monthly-snap"} 0
+solidfire_schedule_volumes{schedule_id="1",schedule_name="daily-snap"} 2
+solidfire_schedule_volumes{schedule_id="2",schedule_name="hourly-snap"} 1
+solidfire_schedule_volumes{schedule_id="3",schedule_name="monthly-snap"} 1
+solidfire_scrape_collector_success{collector="accounts"} 1
+solidfire_scrape_collector_success{collector="async_results"} 1
+solidfire_scrape_collector_success{collector="bulk_volume_jobs"} 1
+solidfire_scrape_collector_success{collector="cluster_capacity"} 1
+solidfire_scrape_collector_success{collector="cluster_full_threshold"} 1
+solidfire_scrape_collector_success{collector="cluster_info"} 1
+solidfire_scrape_collector_success{collector="cluster_stats"} 1
+solidfire_scrape_collector_success{collector="deleted_volumes"} 1
+solidfire_scrape_collector_success{collector="drives"} 1
+solidfire_scrape_collector_success{collector="ensemble"} 1
+solidfire_scrape_collector_success{collector="faults"} 1
+solidfire_scrape_collector_success{collector="initiators"} 1
+solidfire_scrape_collector_success{collector="iscsi"} 1
+solidfire_scrape_collector_success{collector="node_meta"} 1
+solidfire_scrape_collector_success{collector="node_stats"} 1
+solidfire_scrape_collector_success{collector="qos_histograms"} 1
+solidfire_scrape_collector_success{collector="replication"} 1
+solidfire_scrape_collector_success{collector="schedules"} 1
+solidfire_scrape_collector_success{collector="virtual_volume_tasks"} 1
+solidfire_scrape_collector_success{collector="volume_access_groups"} 1
+solidfire_scrape_collector_success{collector="volume_meta"} 0
+solidfire_scrape_collector_success{collector="volume_qos"} 0
+solidfire_scrape_collector_success{collector="volume_state"} 0
+solidfire_scrape_collector_success{collector="volume_stats"} 1
+solidfire_up 1
+solidfire_volume_actual_iops{account_id="",volume_id="1",volume_name=""} 0
+solidfire_volume_actual_iops{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_average_iop_size_bytes{account_id="",volume_id="1",volume_name=""} 0
+solidfire_volume_average_iop_size_bytes{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_burst_iops_credit{account_id="",volume_id="1",volume_name=""} 600000
+solidfire_volume_burst_iops_credit{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_client_queue_depth{account_id="",volume_id="1",volume_name=""} 0
+solidfire_volume_client_queue_depth{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_latency_seconds{account_id="",volume_id="1",volume_name=""} 0
+solidfire_volume_latency_seconds{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_non_zero_blocks{account_id="",volume_id="1",volume_name=""} 165133
+solidfire_volume_non_zero_blocks{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_pair_info{account_id="",cluster_pair_id="1",mode="Async",remote_volume_id="8",remote_volume_name="test-volume1-dr",volume_id="1",volume_name="test-volume1"} 1
+solidfire_volume_pair_last_replicated_snapshot_timestamp_seconds{account_id="",cluster_pair_id="1",volume_id="1",volume_name="test-volume1"} 1.6172352e+09
+solidfire_volume_pair_snapshot_replication_state{account_id="",cluster_pair_id="1",state="Idle",volume_id="1",volume_name="test-volume1"} 1
+solidfire_volume_pair_state{account_id="",cluster_pair_id="1",state="Active",volume_id="1",volume_name="test-volume1"} 1
+solidfire_volume_purge_timestamp_seconds{account_id="test_owner",volume_id="3",volume_name="test-volume3"} 1.618896624e+09
+solidfire_volume_qos_below_min_iops_percentage_bucket{account_id="",volume_id="1",volume_name="",le="+Inf"} 0
+solidfire_volume_qos_below_min_iops_percentage_bucket{account_id="",volume_id="1",volume_name="",le="100"} 4
+solidfire_volume_qos_below_min_iops_percentage_bucket{account_id="",volume_id="1",volume_name="",le="19"} 32
+solidfire_volume_qos_below_min_iops_percentage_bucket{account_id="",volume_id="1",volume_name="",le="39"} 6
+solidfire_volume_qos_below_min_iops_percentage_bucket{account_id="",volume_id="1",volume_name="",le="59"} 4
+solidfire_volume_qos_below_min_iops_percentage_bucket{account_id="",volume_id="1",volume_name="",le="79"} 2
+solidfire_volume_qos_below_min_iops_percentage_bucket{account_id="",volume_id="2",volume_name="",le="+Inf"} 0
+solidfire_volume_qos_below_min_iops_percentage_bucket{account_id="",volume_id="2",volume_name="",le="100"} 0
+solidfire_volume_qos_below_min_iops_percentage_bucket{account_id="",volume_id="2",volume_name="",le="19"} 0
+solidfire_volume_qos_below_min_iops_percentage_bucket{account_id="",volume_id="2",volume_name="",le="39"} 0
+solidfire_volume_qos_below_min_iops_percentage_bucket{account_id="",volume_id="2",volume_name="",le="59"} 0
+solidfire_volume_qos_below_min_iops_percentage_bucket{account_id="",volume_id="2",volume_name="",le="79"} 0
+solidfire_volume_qos_below_min_iops_percentage_count{account_id="",volume_id="1",volume_name=""} 0
+solidfire_volume_qos_below_min_iops_percentage_count{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_qos_below_min_iops_percentage_sum{account_id="",volume_id="1",volume_name=""} 48
+solidfire_volume_qos_below_min_iops_percentage_sum{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_qos_min_to_max_iops_percentage_bucket{account_id="",volume_id="1",volume_name="",le="+Inf"} 6162
+solidfire_volume_qos_min_to_max_iops_percentage_bucket{account_id="",volume_id="1",volume_name="",le="100"} 28539
+solidfire_volume_qos_min_to_max_iops_percentage_bucket{account_id="",volume_id="1",volume_name="",le="19"} 167
+solidfire_volume_qos_min_to_max_iops_percentage_bucket{account_id="",volume_id="1",volume_name="",le="39"} 3823
+solidfire_volume_qos_min_to_max_iops_percentage_bucket{account_id="",volume_id="1",volume_name="",le="59"} 2304
+solidfire_volume_qos_min_to_max_iops_percentage_bucket{account_id="",volume_id="1",volume_name="",le="79"} 5867
+solidfire_volume_qos_min_to_max_iops_percentage_bucket{account_id="",volume_id="2",volume_name="",le="+Inf"} 0
+solidfire_volume_qos_min_to_max_iops_percentage_bucket{account_id="",volume_id="2",volume_name="",le="100"} 0
+solidfire_volume_qos_min_to_max_iops_percentage_bucket{account_id="",volume_id="2",volume_name="",le="19"} 0
+solidfire_volume_qos_min_to_max_iops_percentage_bucket{account_id="",volume_id="2",volume_name="",le="39"} 0
+solidfire_volume_qos_min_to_max_iops_percentage_bucket{account_id="",volume_id="2",volume_name="",le="59"} 0
+solidfire_volume_qos_min_to_max_iops_percentage_bucket{account_id="",volume_id="2",volume_name="",le="79"} 0
+solidfire_volume_qos_min_to_max_iops_percentage_count{account_id="",volume_id="1",volume_name=""} 0
+solidfire_volume_qos_min_to_max_iops_percentage_count{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_qos_min_to_max_iops_percentage_sum{account_id="",volume_id="1",volume_name=""} 46862
+solidfire_volume_qos_min_to_max_iops_percentage_sum{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_qos_read_block_sizes_bytes_bucket{account_id="",volume_id="1",volume_name="",le="+Inf"} 16
+solidfire_volume_qos_read_block_sizes_bytes_bucket{account_id="",volume_id="1",volume_name="",le="131071"} 39
+solidfire_volume_qos_read_block_sizes_bytes_bucket{account_id="",volume_id="1",volume_name="",le="16383"} 27
+solidfire_volume_qos_read_block_sizes_bytes_bucket{account_id="",volume_id="1",volume_name="",le="32767"} 78
+solidfire_volume_qos_read_block_sizes_bytes_bucket{account_id="",volume_id="1",volume_name="",le="65535"} 62
+solidfire_volume_qos_read_block_sizes_bytes_bucket{account_id="",volume_id="1",volume_name="",le="8191"} 1.1091915e+07
+solidfire_volume_qos_read_block_sizes_bytes_bucket{account_id="",volume_id="2",volume_name="",le="+Inf"} 0
+solidfire_volume_qos_read_block_sizes_bytes_bucket{account_id="",volume_id="2",volume_name="",le="131071"} 0
+solidfire_volume_qos_read_block_sizes_bytes_bucket{account_id="",volume_id="2",volume_name="",le="16383"} 0
+solidfire_volume_qos_read_block_sizes_bytes_bucket{account_id="",volume_id="2",volume_name="",le="32767"} 0
+solidfire_volume_qos_read_block_sizes_bytes_bucket{account_id="",volume_id="2",volume_name="",le="65535"} 0
+solidfire_volume_qos_read_block_sizes_bytes_bucket{account_id="",volume_id="2",volume_name="",le="8191"} 0
+solidfire_volume_qos_read_block_sizes_bytes_count{account_id="",volume_id="1",volume_name=""} 0
+solidfire_volume_qos_read_block_sizes_bytes_count{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_qos_read_block_sizes_bytes_sum{account_id="",volume_id="1",volume_name=""} 1.1092137e+07
+solidfire_volume_qos_read_block_sizes_bytes_sum{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_qos_target_utilization_percentage_bucket{account_id="",volume_id="1",volume_name="",le="+Inf"} 6162
+solidfire_volume_qos_target_utilization_percentage_bucket{account_id="",volume_id="1",volume_name="",le="0"} 5.755624e+06
+solidfire_volume_qos_target_utilization_percentage_bucket{account_id="",volume_id="1",volume_name="",le="100"} 28690
+solidfire_volume_qos_target_utilization_percentage_bucket{account_id="",volume_id="1",volume_name="",le="19"} 157
+solidfire_volume_qos_target_utilization_percentage_bucket{account_id="",volume_id="1",volume_name="",le="39"} 3778
+solidfire_volume_qos_target_utilization_percentage_bucket{account_id="",volume_id="1",volume_name="",le="59"} 2277
+solidfire_volume_qos_target_utilization_percentage_bucket{account_id="",volume_id="1",volume_name="",le="79"} 5812
+solidfire_volume_qos_target_utilization_percentage_bucket{account_id="",volume_id="2",volume_name="",le="+Inf"} 0
+solidfire_volume_qos_target_utilization_percentage_bucket{account_id="",volume_id="2",volume_name="",le="0"} 0
+solidfire_volume_qos_target_utilization_percentage_bucket{account_id="",volume_id="2",volume_name="",le="100"} 0
+solidfire_volume_qos_target_utilization_percentage_bucket{account_id="",volume_id="2",volume_name="",le="19"} 0
+solidfire_volume_qos_target_utilization_percentage_bucket{account_id="",volume_id="2",volume_name="",le="39"} 0
+solidfire_volume_qos_target_utilization_percentage_bucket{account_id="",volume_id="2",volume_name="",le="59"} 0
+solidfire_volume_qos_target_utilization_percentage_bucket{account_id="",volume_id="2",volume_name="",le="79"} 0
+solidfire_volume_qos_target_utilization_percentage_count{account_id="",volume_id="1",volume_name=""} 0
+solidfire_volume_qos_target_utilization_percentage_count{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_qos_target_utilization_percentage_sum{account_id="",volume_id="1",volume_name=""} 5.8025e+06
+solidfire_volume_qos_target_utilization_percentage_sum{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_qos_throttle_percentage_bucket{account_id="",volume_id="1",volume_name="",le="+Inf"} 0
+solidfire_volume_qos_throttle_percentage_bucket{account_id="",volume_id="1",volume_name="",le="0"} 5.8025e+06
+solidfire_volume_qos_throttle_percentage_bucket{account_id="",volume_id="1",volume_name="",le="100"} 0
+solidfire_volume_qos_throttle_percentage_bucket{account_id="",volume_id="1",volume_name="",le="19"} 0
+solidfire_volume_qos_throttle_percentage_bucket{account_id="",volume_id="1",volume_name="",le="39"} 0
+solidfire_volume_qos_throttle_percentage_bucket{account_id="",volume_id="1",volume_name="",le="59"} 0
+solidfire_volume_qos_throttle_percentage_bucket{account_id="",volume_id="1",volume_name="",le="79"} 0
+solidfire_volume_qos_throttle_percentage_bucket{account_id="",volume_id="2",volume_name="",le="+Inf"} 0
+solidfire_volume_qos_throttle_percentage_bucket{account_id="",volume_id="2",volume_name="",le="0"} 0
+solidfire_volume_qos_throttle_percentage_bucket{account_id="",volume_id="2",volume_name="",le="100"} 0
+solidfire_volume_qos_throttle_percentage_bucket{account_id="",volume_id="2",volume_name="",le="19"} 0
+solidfire_volume_qos_throttle_percentage_bucket{account_id="",volume_id="2",volume_name="",le="39"} 0
+solidfire_volume_qos_throttle_percentage_bucket{account_id="",volume_id="2",volume_name="",le="59"} 0
+solidfire_volume_qos_throttle_percentage_bucket{account_id="",volume_id="2",volume_name="",le="79"} 0
+solidfire_volume_qos_throttle_percentage_count{account_id="",volume_id="1",volume_name=""} 0
+solidfire_volume_qos_throttle_percentage_count{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_qos_throttle_percentage_sum{account_id="",volume_id="1",volume_name=""} 5.8025e+06
+solidfire_volume_qos_throttle_percentage_sum{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_qos_write_block_sizes_bytes_bucket{account_id="",volume_id="1",volume_name="",le="+Inf"} 1771
+solidfire_volume_qos_write_block_sizes_bytes_bucket{account_id="",volume_id="1",volume_name="",le="131071"} 43454
+solidfire_volume_qos_write_block_sizes_bytes_bucket{account_id="",volume_id="1",volume_name="",le="16383"} 4.182242e+06
+solidfire_volume_qos_write_block_sizes_bytes_bucket{account_id="",volume_id="1",volume_name="",le="32767"} 1.634812e+06
+solidfire_volume_qos_write_block_sizes_bytes_bucket{account_id="",volume_id="1",volume_name="",le="65535"} 368092
+solidfire_volume_qos_write_block_sizes_bytes_bucket{account_id="",volume_id="1",volume_name="",le="8191"} 6.859016e+06
+solidfire_volume_qos_write_block_sizes_bytes_bucket{account_id="",volume_id="2",volume_name="",le="+Inf"} 0
+solidfire_volume_qos_write_block_sizes_bytes_bucket{account_id="",volume_id="2",volume_name="",le="131071"} 0
+solidfire_volume_qos_write_block_sizes_bytes_bucket{account_id="",volume_id="2",volume_name="",le="16383"} 0
+solidfire_volume_qos_write_block_sizes_bytes_bucket{account_id="",volume_id="2",volume_name="",le="32767"} 0
+solidfire_volume_qos_write_block_sizes_bytes_bucket{account_id="",volume_id="2",volume_name="",le="65535"} 0
+solidfire_volume_qos_write_block_sizes_bytes_bucket{account_id="",volume_id="2",volume_name="",le="8191"} 0
+solidfire_volume_qos_write_block_sizes_bytes_count{account_id="",volume_id="1",volume_name=""} 0
+solidfire_volume_qos_write_block_sizes_bytes_count{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_qos_write_block_sizes_bytes_sum{account_id="",volume_id="1",volume_name=""} 1.3089387e+07
+solidfire_volume_qos_write_block_sizes_bytes_sum{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_read_bytes_total{account_id="",volume_id="1",volume_name=""} 4.5445102592e+10
+solidfire_volume_read_bytes_total{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_read_latency_seconds_total{account_id="",volume_id="1",volume_name=""} 0
+solidfire_volume_read_latency_seconds_total{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_read_ops_total{account_id="",volume_id="1",volume_name=""} 1.109215e+07
+solidfire_volume_read_ops_total{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_size_bytes{account_id="",volume_id="1",volume_name=""} 2.000683008e+09
+solidfire_volume_size_bytes{account_id="",volume_id="2",volume_name=""} 4.00031744e+09
+solidfire_volume_throttle{account_id="",volume_id="1",volume_name=""} 0
+solidfire_volume_throttle{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_unaligned_reads_total{account_id="",volume_id="1",volume_name=""} 13
+solidfire_volume_unaligned_reads_total{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_unaligned_writes_total{account_id="",volume_id="1",volume_name=""} 0
+solidfire_volume_unaligned_writes_total{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_utilization{account_id="",volume_id="1",volume_name=""} 0
+solidfire_volume_utilization{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_write_bytes_total{account_id="",volume_id="1",volume_name=""} 1.21720639488e+11
+solidfire_volume_write_bytes_total{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_write_latency_seconds_total{account_id="",volume_id="1",volume_name=""} 0
+solidfire_volume_write_latency_seconds_total{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_write_ops_total{account_id="",volume_id="1",volume_name=""} 1.3089387e+07
+solidfire_volume_write_ops_total{account_id="",volume_id="2",volume_name=""} 0
+solidfire_volume_zero_blocks{account_id="",volume_id="1",volume_name=""} 323315
+solidfire_volume_zero_blocks{account_id="",volume_id="2",volume_name=""} 976640
 `), "\n")
 
 var CollectOutputVolumeStatsErr = strings.Split(strings.TrimSpace(`
@@ -311,9 +758,13 @@ solidfire_cluster_client_queue_depth 0
 solidfire_cluster_compression_factor 2.094133784391091
 solidfire_cluster_current_iops 0
 solidfire_cluster_de_duplication_factor 1.0000545044935927
+solidfire_cluster_deleted_volume_bytes 1.073741824e+09
+solidfire_cluster_deleted_volume_count 1
 solidfire_cluster_efficiency_factor 18.580522988214764
+solidfire_cluster_ensemble_size 1
 solidfire_cluster_fullness{level="blockFullness"} 0
 solidfire_cluster_fullness{level="metadataFullness"} 0
+solidfire_cluster_info{cluster_name="sfcluster01",encryption_at_rest_state="disabled",ensemble="10.0.0.91",mvip="10.0.0.90",replica_count="2",software_encryption_at_rest_state="enabled",svip="10.0.1.90",unique_id="1mhp",uuid="7c8bbb67-cf7d-4dd1-8a63-a16cd2e1d2d3"} 1
 solidfire_cluster_initiator_count 1
 solidfire_cluster_iops 0
 solidfire_cluster_iops_total 2.4181537e+07
@@ -322,6 +773,7 @@ solidfire_cluster_last_sample_read_ops 0
 solidfire_cluster_last_sample_write_bytes 0
 solidfire_cluster_last_sample_write_ops 0
 solidfire_cluster_latency_seconds 0
+solidfire_cluster_master_changes_total 0
 solidfire_cluster_max_async_result_id 47
 solidfire_cluster_max_iops 3000
 solidfire_cluster_max_metadata_over_provision_factor 5
@@ -334,8 +786,13 @@ solidfire_cluster_metadata_fullness{level="stage2Aware"} 0
 solidfire_cluster_metadata_fullness{level="stage3Low"} 0
 solidfire_cluster_metadata_fullness{level="stage4Critical"} 0
 solidfire_cluster_metadata_fullness{level="stage5CompletelyConsumed"} 0
+solidfire_cluster_node_versions 1
 solidfire_cluster_non_zero_blocks 165133
 solidfire_cluster_normalized_iops 0
+solidfire_cluster_pair_latency_seconds{cluster_name="dr-cluster",cluster_pair_id="1"} 0.002
+solidfire_cluster_pair_status{cluster_name="dr-cluster",cluster_pair_id="1",status="Connected"} 1
+solidfire_cluster_pair_status{cluster_name="dr-cluster",cluster_pair_id="1",status="Disconnected"} 0
+solidfire_cluster_pair_status{cluster_name="dr-cluster",cluster_pair_id="1",status="Misconfigured"} 0
 solidfire_cluster_peak_active_sessions 1
 solidfire_cluster_peak_iops 6
 solidfire_cluster_provisioned_space_bytes 6.001000448e+09
@@ -365,11 +822,13 @@ solidfire_cluster_unaligned_reads_total 13
 solidfire_cluster_unaligned_writes_total 0
 solidfire_cluster_unique_blocks 165124
 solidfire_cluster_unique_blocks_used_space_bytes 3.47282402e+08
+solidfire_cluster_upgrade_pending 0
 solidfire_cluster_used_bytes 3.47282402e+08
 solidfire_cluster_used_metadata_bytes 7.221248e+06
 solidfire_cluster_used_metadata_space_bytes 7.221248e+06
 solidfire_cluster_used_metadata_space_in_snapshots_bytes 7.221248e+06
 solidfire_cluster_used_space_bytes 3.47282402e+08
+solidfire_cluster_version_info{cluster_api_version="12.3",cluster_version="12.3.0.958",pending_cluster_version="12.3.0.958"} 1
 solidfire_cluster_volume_access_group_count 1
 solidfire_cluster_volume_async_result_active{type="BulkVolume"} 0
 solidfire_cluster_volume_async_result_active{type="Clone"} 0
@@ -413,8 +872,16 @@ solidfire_drive_status{drive_id="4",node_id="1",node_name="n01",serial="sde",slo
 solidfire_drive_status{drive_id="4",node_id="1",node_name="n01",serial="sde",slot="4",status="erasing",type="block"} 0
 solidfire_drive_status{drive_id="4",node_id="1",node_name="n01",serial="sde",slot="4",status="failed",type="block"} 0
 solidfire_drive_status{drive_id="4",node_id="1",node_name="n01",serial="sde",slot="4",status="removing",type="block"} 0
+solidfire_inventory_changes_total{change="added",kind="node"} 0
+solidfire_inventory_changes_total{change="added",kind="volume"} 0
+solidfire_inventory_changes_total{change="removed",kind="node"} 0
+solidfire_inventory_changes_total{change="removed",kind="volume"} 0
+solidfire_inventory_objects{kind="node"} 1
+solidfire_inventory_objects{kind="volume"} 2
+solidfire_node_cluster_master{node_id="1",node_name="n01"} 1
 solidfire_node_cpu_percentage{node_id="1",node_name="n01"} 0
 solidfire_node_cpu_seconds_total{node_id="1",node_name="n01"} 2247
+solidfire_node_ensemble_member{node_id="1",node_name="n01"} 1
 solidfire_node_info{associated_fservice_id="0",associated_master_service_id="1",chassis_name="",chassis_type="SFVIRT",cpu_model="Intel(R) Xeon(R) CPU E7-8891 v4 @ 2.80GHz\nUnknown Processor",node_id="1",node_name="n01",node_type="SFDEMO-NE",platform_config_version="0.0.0.0",sip="10.0.0.91",sipi="eth1",software_version="11.7.0.76",uuid="5329FE1F-A41F-DC41-8BD9-2016FF2DD8FF"} 1
 solidfire_node_interface_in_bytes_total{interface="cluster",node_id="1",node_name="n01"} 282366
 solidfire_node_interface_in_bytes_total{interface="management",node_id="1",node_name="n01"} 332883
@@ -438,10 +905,60 @@ solidfire_node_read_latency_seconds_total{node_id="1",node_name="n01"} 0
 solidfire_node_samples{node_id="1",node_name="n01"} 294
 solidfire_node_total_memory_bytes{node_id="1",node_name="n01"} 1.6e+10
 solidfire_node_used_memory_bytes{node_id="1",node_name="n01"} 9.000198144e+09
+solidfire_node_version_info{node_id="1",node_name="n01",node_version="12.3.0.958"} 1
 solidfire_node_write_latency_seconds_total{node_id="1",node_name="n01"} 0
-solidfire_up 0
+solidfire_exporter_api_version_info{api_version="11.3"} 1
+solidfire_schedule_last_run_success{schedule_id="1",schedule_name="daily-snap"} 1
+solidfire_schedule_last_run_success{schedule_id="2",schedule_name="hourly-snap"} 0
+solidfire_schedule_last_run_timestamp_seconds{schedule_id="1",schedule_name="daily-snap"} 1.618538403e+09
+solidfire_schedule_last_run_timestamp_seconds{schedule_id="2",schedule_name="hourly-snap"} 1.6185456e+09
+solidfire_schedule_next_run_timestamp_seconds{schedule_id="1",schedule_name="daily-snap"} 1.6187976e+09
+solidfire_schedule_next_run_timestamp_seconds{schedule_id="3",schedule_name="monthly-snap"} 1.6184466e+09
+solidfire_schedule_paused{schedule_id="1",schedule_name="daily-snap"} 0
+solidfire_schedule_paused{schedule_id="2",schedule_name="hourly-snap"} 1
+solidfire_schedule_paused{schedule_id="3",schedule_name="monthly-snap"} 0
+solidfire_schedule_volumes{schedule_id="1",schedule_name="daily-snap"} 2
+solidfire_schedule_volumes{schedule_id="2",schedule_name="hourly-snap"} 1
+solidfire_schedule_volumes{schedule_id="3",schedule_name="monthly-snap"} 1
+solidfire_scrape_collector_success{collector="accounts"} 1
+solidfire_scrape_collector_success{collector="async_results"} 1
+solidfire_scrape_collector_success{collector="bulk_volume_jobs"} 1
+solidfire_scrape_collector_success{collector="cluster_capacity"} 1
+solidfire_scrape_collector_success{collector="cluster_full_threshold"} 1
+solidfire_scrape_collector_success{collector="cluster_info"} 1
+solidfire_scrape_collector_success{collector="cluster_stats"} 1
+solidfire_scrape_collector_success{collector="deleted_volumes"} 1
+solidfire_scrape_collector_success{collector="drives"} 1
+solidfire_scrape_collector_success{collector="ensemble"} 1
+solidfire_scrape_collector_success{collector="faults"} 1
+solidfire_scrape_collector_success{collector="initiators"} 1
+solidfire_scrape_collector_success{collector="iscsi"} 1
+solidfire_scrape_collector_success{collector="node_meta"} 1
+solidfire_scrape_collector_success{collector="node_stats"} 1
+solidfire_scrape_collector_success{collector="qos_histograms"} 1
+solidfire_scrape_collector_success{collector="replication"} 1
+solidfire_scrape_collector_success{collector="schedules"} 1
+solidfire_scrape_collector_success{collector="virtual_volume_tasks"} 1
+solidfire_scrape_collector_success{collector="volume_access_groups"} 1
+solidfire_scrape_collector_success{collector="volume_meta"} 1
+solidfire_scrape_collector_success{collector="volume_qos"} 1
+solidfire_scrape_collector_success{collector="volume_state"} 1
+solidfire_scrape_collector_success{collector="volume_stats"} 0
+solidfire_up 1
 solidfire_cluster_volume_count{status="active"} 2
 solidfire_cluster_volume_virtual_volume_task_count 1
+solidfire_volume_created_timestamp_seconds{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 1.615264843e+09
+solidfire_volume_created_timestamp_seconds{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 1.618162188e+09
+solidfire_volume_info{access="readWrite",account_name="jamesw",attributes="owner_id=test_owner",block_size="4096",enable512e="true",iqn="iqn.2010-01.com.solidfire:1mhp.test-volume1.1",protection_scheme="singleHelix",scsi_eui_device_id="316d687000000001f47acc0100000000",scsi_naa_device_id="6f47acc100000000316d687000000001",slice_count="1",status="active",volume_access_groups="1",volume_account_id="1",volume_consistency_group_uuid="8918cf90-ccfc-4188-8438-744f891aa4fe",volume_id="1",volume_name="test-volume1",volume_uuid="763a0938-6906-4006-af96-ed42a3ea807e"} 1
+solidfire_volume_info{access="readWrite",account_name="jamesw",attributes="owner_id=test_owner",block_size="4096",enable512e="true",iqn="iqn.2010-01.com.solidfire:1mhp.test-volume2.2",protection_scheme="singleHelix",scsi_eui_device_id="316d687000000002f47acc0100000000",scsi_naa_device_id="6f47acc100000000316d687000000002",slice_count="1",status="active",volume_access_groups="",volume_account_id="1",volume_consistency_group_uuid="b20d084c-68c9-4945-a891-bd4fa0318f16",volume_id="2",volume_name="test-volume2",volume_uuid="6826da37-cce7-41a0-9102-6bc2462b0f85"} 1
+solidfire_volume_last_io_timestamp_seconds{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 1.618547424e+09
+solidfire_volume_pair_info{account_id="test_owner",cluster_pair_id="1",mode="Async",remote_volume_id="8",remote_volume_name="test-volume1-dr",volume_id="1",volume_name="test-volume1"} 1
+solidfire_volume_pair_last_replicated_snapshot_timestamp_seconds{account_id="test_owner",cluster_pair_id="1",volume_id="1",volume_name="test-volume1"} 1.6172352e+09
+solidfire_volume_pair_snapshot_replication_state{account_id="test_owner",cluster_pair_id="1",state="Idle",volume_id="1",volume_name="test-volume1"} 1
+solidfire_volume_pair_state{account_id="test_owner",cluster_pair_id="1",state="Active",volume_id="1",volume_name="test-volume1"} 1
+solidfire_volume_provisioned_bytes{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 2.000683008e+09
+solidfire_volume_provisioned_bytes{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 4.00031744e+09
+solidfire_volume_purge_timestamp_seconds{account_id="test_owner",volume_id="3",volume_name="test-volume3"} 1.618896624e+09
 solidfire_volume_qos_below_min_iops_percentage_bucket{account_id="test_owner",volume_id="1",volume_name="test-volume1",le="19"} 32
 solidfire_volume_qos_below_min_iops_percentage_bucket{account_id="test_owner",volume_id="1",volume_name="test-volume1",le="39"} 6
 solidfire_volume_qos_below_min_iops_percentage_bucket{account_id="test_owner",volume_id="1",volume_name="test-volume1",le="59"} 4
@@ -458,6 +975,32 @@ solidfire_volume_qos_below_min_iops_percentage_bucket{account_id="test_owner",vo
 solidfire_volume_qos_below_min_iops_percentage_bucket{account_id="test_owner",volume_id="2",volume_name="test-volume2",le="+Inf"} 0
 solidfire_volume_qos_below_min_iops_percentage_sum{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 0
 solidfire_volume_qos_below_min_iops_percentage_count{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 0
+solidfire_volume_qos_burst_iops{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 15000
+solidfire_volume_qos_burst_iops{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 15000
+solidfire_volume_qos_burst_time_seconds{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 60
+solidfire_volume_qos_burst_time_seconds{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 60
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="1048576",volume_id="1",volume_name="test-volume1"} 15000
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="1048576",volume_id="2",volume_name="test-volume2"} 15000
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="131072",volume_id="1",volume_name="test-volume1"} 1950
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="131072",volume_id="2",volume_name="test-volume2"} 1950
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="16384",volume_id="1",volume_name="test-volume1"} 270
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="16384",volume_id="2",volume_name="test-volume2"} 270
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="262144",volume_id="1",volume_name="test-volume1"} 3900
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="262144",volume_id="2",volume_name="test-volume2"} 3900
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="32768",volume_id="1",volume_name="test-volume1"} 500
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="32768",volume_id="2",volume_name="test-volume2"} 500
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="4096",volume_id="1",volume_name="test-volume1"} 100
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="4096",volume_id="2",volume_name="test-volume2"} 100
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="524288",volume_id="1",volume_name="test-volume1"} 7600
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="524288",volume_id="2",volume_name="test-volume2"} 7600
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="65536",volume_id="1",volume_name="test-volume1"} 1000
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="65536",volume_id="2",volume_name="test-volume2"} 1000
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="8192",volume_id="1",volume_name="test-volume1"} 160
+solidfire_volume_qos_curve{account_id="test_owner",io_size_bytes="8192",volume_id="2",volume_name="test-volume2"} 160
+solidfire_volume_qos_max_iops{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 5000
+solidfire_volume_qos_max_iops{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 5000
+solidfire_volume_qos_min_iops{account_id="test_owner",volume_id="1",volume_name="test-volume1"} 50
+solidfire_volume_qos_min_iops{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 50
 solidfire_volume_qos_min_to_max_iops_percentage_bucket{account_id="test_owner",volume_id="1",volume_name="test-volume1",le="19"} 167
 solidfire_volume_qos_min_to_max_iops_percentage_bucket{account_id="test_owner",volume_id="1",volume_name="test-volume1",le="39"} 3823
 solidfire_volume_qos_min_to_max_iops_percentage_bucket{account_id="test_owner",volume_id="1",volume_name="test-volume1",le="59"} 2304
@@ -474,6 +1017,7 @@ solidfire_volume_qos_min_to_max_iops_percentage_bucket{account_id="test_owner",v
 solidfire_volume_qos_min_to_max_iops_percentage_bucket{account_id="test_owner",volume_id="2",volume_name="test-volume2",le="+Inf"} 0
 solidfire_volume_qos_min_to_max_iops_percentage_sum{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 0
 solidfire_volume_qos_min_to_max_iops_percentage_count{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 0
+solidfire_volume_qos_policy_info{account_id="test_owner",qos_policy_id="1",qos_policy_name="gold",volume_id="1",volume_name="test-volume1"} 1
 solidfire_volume_qos_read_block_sizes_bytes_bucket{account_id="test_owner",volume_id="1",volume_name="test-volume1",le="8191"} 1.1091915e+07
 solidfire_volume_qos_read_block_sizes_bytes_bucket{account_id="test_owner",volume_id="1",volume_name="test-volume1",le="16383"} 27
 solidfire_volume_qos_read_block_sizes_bytes_bucket{account_id="test_owner",volume_id="1",volume_name="test-volume1",le="32767"} 78
@@ -542,4 +1086,16 @@ solidfire_volume_qos_write_block_sizes_bytes_bucket{account_id="test_owner",volu
 solidfire_volume_qos_write_block_sizes_bytes_bucket{account_id="test_owner",volume_id="2",volume_name="test-volume2",le="+Inf"} 0
 solidfire_volume_qos_write_block_sizes_bytes_sum{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 0
 solidfire_volume_qos_write_block_sizes_bytes_count{account_id="test_owner",volume_id="2",volume_name="test-volume2"} 0
+solidfire_volume_state{account_id="test_owner",state="active",volume_id="1",volume_name="test-volume1"} 0
+solidfire_volume_state{account_id="test_owner",state="active",volume_id="2",volume_name="test-volume2"} 0
+solidfire_volume_state{account_id="test_owner",state="active",volume_id="3",volume_name="test-volume3"} 0
+solidfire_volume_state{account_id="test_owner",state="deleted",volume_id="1",volume_name="test-volume1"} 0
+solidfire_volume_state{account_id="test_owner",state="deleted",volume_id="2",volume_name="test-volume2"} 0
+solidfire_volume_state{account_id="test_owner",state="deleted",volume_id="3",volume_name="test-volume3"} 1
+solidfire_volume_state{account_id="test_owner",state="idle",volume_id="1",volume_name="test-volume1"} 1
+solidfire_volume_state{account_id="test_owner",state="idle",volume_id="2",volume_name="test-volume2"} 0
+solidfire_volume_state{account_id="test_owner",state="idle",volume_id="3",volume_name="test-volume3"} 0
+solidfire_volume_state{account_id="test_owner",state="unmapped",volume_id="1",volume_name="test-volume1"} 0
+solidfire_volume_state{account_id="test_owner",state="unmapped",volume_id="2",volume_name="test-volume2"} 1
+solidfire_volume_state{account_id="test_owner",state="unmapped",volume_id="3",volume_name="test-volume3"} 0
 `), "\n")
@@ -79,3 +79,63 @@ func (m *MockSolidfireClient) ListBulkVolumeJobs(ctx context.Context) (solidfire
 	args := m.Called(ctx)
 	return args.Get(0).(solidfire.ListBulkVolumeJobsResponse), args.Error(1)
 }
+func (m *MockSolidfireClient) GetClusterInfo(ctx context.Context) (solidfire.GetClusterInfoResponse, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(solidfire.GetClusterInfoResponse), args.Error(1)
+}
+func (m *MockSolidfireClient) ListQoSPolicies(ctx context.Context) (solidfire.ListQoSPoliciesResponse, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(solidfire.ListQoSPoliciesResponse), args.Error(1)
+}
+func (m *MockSolidfireClient) ListDeletedVolumes(ctx context.Context) (solidfire.ListDeletedVolumesResponse, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(solidfire.ListDeletedVolumesResponse), args.Error(1)
+}
+func (m *MockSolidfireClient) ListSnapshots(ctx context.Context) (solidfire.ListSnapshotsResponse, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(solidfire.ListSnapshotsResponse), args.Error(1)
+}
+func (m *MockSolidfireClient) ListGroupSnapshots(ctx context.Context) (solidfire.ListGroupSnapshotsResponse, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(solidfire.ListGroupSnapshotsResponse), args.Error(1)
+}
+func (m *MockSolidfireClient) ListSchedules(ctx context.Context) (solidfire.ListSchedulesResponse, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(solidfire.ListSchedulesResponse), args.Error(1)
+}
+func (m *MockSolidfireClient) ListClusterPairs(ctx context.Context) (solidfire.ListClusterPairsResponse, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(solidfire.ListClusterPairsResponse), args.Error(1)
+}
+func (m *MockSolidfireClient) ListActivePairedVolumes(ctx context.Context) (solidfire.ListActivePairedVolumesResponse, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(solidfire.ListActivePairedVolumesResponse), args.Error(1)
+}
+func (m *MockSolidfireClient) ListSnapMirrorEndpoints(ctx context.Context) (solidfire.ListSnapMirrorEndpointsResponse, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(solidfire.ListSnapMirrorEndpointsResponse), args.Error(1)
+}
+func (m *MockSolidfireClient) ListSnapMirrorRelationships(ctx context.Context) (solidfire.ListSnapMirrorRelationshipsResponse, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(solidfire.ListSnapMirrorRelationshipsResponse), args.Error(1)
+}
+func (m *MockSolidfireClient) GetClusterVersionInfo(ctx context.Context) (solidfire.GetClusterVersionInfoResponse, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(solidfire.GetClusterVersionInfoResponse), args.Error(1)
+}
+func (m *MockSolidfireClient) GetClusterMasterNodeID(ctx context.Context) (solidfire.GetClusterMasterNodeIDResponse, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(solidfire.GetClusterMasterNodeIDResponse), args.Error(1)
+}
+func (m *MockSolidfireClient) GetIpmiInfo(ctx context.Context) (solidfire.GetIpmiInfoResponse, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(solidfire.GetIpmiInfoResponse), args.Error(1)
+}
+func (m *MockSolidfireClient) GetNodeHardwareInfo(ctx context.Context, nodeID int) (solidfire.GetNodeHardwareInfoResponse, error) {
+	args := m.Called(ctx, nodeID)
+	return args.Get(0).(solidfire.GetNodeHardwareInfoResponse), args.Error(1)
+}
+func (m *MockSolidfireClient) APIVersion() string {
+	args := m.Called()
+	return args.String(0)
+}